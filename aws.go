@@ -0,0 +1,22 @@
+package config
+
+import (
+	"context"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+// newAwsConfig returns an AWS config for given region. If region is nil it will try to get
+// current AWS region from environment variable AWS_REGION and falls back to the region
+// resolved by default config chain.
+func newAwsConfig(region *string) (aws.Config, error) {
+
+	if region != nil {
+		return config.LoadDefaultConfig(context.TODO(), config.WithRegion(*region))
+	} else if envRegion, ok := os.LookupEnv("AWS_REGION"); ok {
+		return config.LoadDefaultConfig(context.TODO(), config.WithRegion(envRegion))
+	}
+	return config.LoadDefaultConfig(context.TODO())
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	gzipMagicBytes = []byte{0x1f, 0x8b}
+	zstdMagicBytes = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// compressionExtensions are file extensions of supported compression formats.
+var compressionExtensions = []string{".gz", ".gzip", ".zst", ".zstd"}
+
+// decompress returns decompressed content if passed content is compressed with gzip or zstd.
+// Compression is detected by passed content encoding, e.g. from a Content-Encoding header,
+// or by magic bytes at the beginning of the content. Uncompressed content is returned as is.
+func decompress(content []byte, contentEncoding string) ([]byte, error) {
+
+	contentEncoding = strings.ToLower(strings.TrimSpace(contentEncoding))
+	switch {
+	case contentEncoding == "gzip" || contentEncoding == "x-gzip" || bytes.HasPrefix(content, gzipMagicBytes):
+		reader, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return io.ReadAll(reader)
+
+	case contentEncoding == "zstd" || bytes.HasPrefix(content, zstdMagicBytes):
+		reader, err := zstd.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return io.ReadAll(reader)
+
+	default:
+		return content, nil
+	}
+}
+
+// trimCompressionExtension removes extension of a supported compression format from passed
+// file name, e.g. config.yml.gz will be returned as config.yml.
+func trimCompressionExtension(fileName string) string {
+
+	extension := strings.ToLower(filepath.Ext(fileName))
+	for _, compressionExtension := range compressionExtensions {
+		if extension == compressionExtension {
+			return fileName[:len(fileName)-len(extension)]
+		}
+	}
+	return fileName
+}
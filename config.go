@@ -1,4 +1,5 @@
-// Package config provides access to config from different sources in YAML format.
+// Package config provides access to config from different sources and in different formats,
+// e.g. YAML, JSON, TOML, Java properties or dotenv.
 // Uses viper config from github.com/spf13/viper to load and access config values.
 package config
 
@@ -1,9 +1,32 @@
 package config
 
 import (
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"flag"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing/fstest"
+	"testing/iotest"
 	"time"
 
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/klauspost/compress/zstd"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/suite"
 	//"log"
@@ -127,6 +150,21 @@ func (suite *ConfigTestSuite) TestFileConfigSource() {
 	suite.Nil(config)
 }
 
+func (suite *ConfigTestSuite) TestFileConfigSourceWithoutConfigFile() {
+
+	homeDir := suite.T().TempDir()
+	suite.T().Setenv("HOME", homeDir)
+	suite.T().Chdir(suite.T().TempDir())
+
+	for _, configSource := range []ConfigSource{NewConfigSource(), NewFileConfigSource(nil)} {
+		config, err := configSource.Load()
+		suite.Nil(config)
+		suite.ErrorAs(err, &viper.ConfigFileNotFoundError{})
+		suite.Contains(err.Error(), filepath.Join(homeDir, "go_config"))
+		suite.NotContains(err.Error(), "$HOME")
+	}
+}
+
 func (suite *ConfigTestSuite) testConfigSource(configSource ConfigSource) {
 
 	config, err := configSource.Load()
@@ -336,3 +374,1372 @@ func (suite *ConfigTestSuite) TestUnmarshalInvalidStruct() {
 
 	suite.NotNil(err)
 }
+
+func (suite *ConfigTestSuite) TestSubConfig() {
+
+	config, err := NewStaticConfigSource(suite.staticConfigForTest()).Load()
+	suite.Nil(err)
+
+	durations := config.Sub("durations")
+	suite.NotNil(durations)
+	suite.Equal(43*time.Second, *durations.GetAsDuration("seconds", nil))
+	suite.Equal(5*time.Hour, *durations.GetAsDuration("hours", nil))
+	suite.Nil(durations.Get("key2", nil))
+
+	namespace1 := config.Sub("namespace1")
+	suite.NotNil(namespace1)
+	suite.Equal("value1", *namespace1.Get("key1", nil))
+
+	nestedConfig, err := NewStaticConfigSource("database:\n  primary:\n    host: localhost\n    port: 5432\n").Load()
+	suite.Nil(err)
+	primary := nestedConfig.Sub("database").Sub("primary")
+	suite.NotNil(primary)
+	suite.Equal("localhost", *primary.Get("host", nil))
+	suite.Equal(5432, *primary.GetAsInt("port", nil))
+	suite.Equal(primary.Get("host", nil), nestedConfig.Sub("database.primary").Get("host", nil))
+
+	for _, key := range []string{"xxx", "key2", "xxx.yyy"} {
+		emptyConfig := config.Sub(key)
+		suite.NotNil(emptyConfig, key)
+		suite.Empty(emptyConfig.Keys(), key)
+		suite.Nil(emptyConfig.Get("host", nil), key)
+		suite.Equal("localhost", *emptyConfig.Get("host", AsStringPtr("localhost")), key)
+		suite.Nil(emptyConfig.Sub("primary").Get("host", nil), key)
+	}
+}
+
+func (suite *ConfigTestSuite) TestConfigKeys() {
+
+	config, err := NewStaticConfigSource("namespace1:\n  key1: value1\nkey2: value2\nslice:\n  - a\n  - b\n").Load()
+	suite.Nil(err)
+
+	suite.Equal([]string{"key2", "namespace1.key1", "slice"}, config.Keys())
+
+	suite.True(config.Has("key2"))
+	suite.True(config.Has("namespace1"))
+	suite.True(config.Has("namespace1.key1"))
+	suite.True(config.Has("slice"))
+	suite.False(config.Has("xxx"))
+	suite.False(config.Has("namespace1.xxx"))
+
+	suite.Equal(map[string]any{
+		"namespace1": map[string]any{"key1": "value1"},
+		"key2":       "value2",
+		"slice":      []any{"a", "b"},
+	}, config.AllSettings())
+
+	suite.Equal([]string{"key1"}, config.Sub("namespace1").Keys())
+}
+
+func (suite *ConfigTestSuite) TestStrictAccessors() {
+
+	config, err := NewStaticConfigSource(suite.staticConfigForTest() + "\ninvalid: abc\nfloat: 1.5\nbig: 99999999999999999999\nbools:\n  str: \"false\"\n  num: 1\n").Load()
+	suite.Nil(err)
+
+	intValue, err := config.GetIntE("key3")
+	suite.Nil(err)
+	suite.Equal(12345, intValue)
+
+	boolValue, err := config.GetBoolE("boolval")
+	suite.Nil(err)
+	suite.True(boolValue)
+	boolValue, err = config.GetBoolE("bools.str")
+	suite.Nil(err)
+	suite.False(boolValue)
+	boolValue, err = config.GetBoolE("bools.num")
+	suite.Nil(err)
+	suite.True(boolValue)
+
+	durationValue, err := config.GetDurationE("durations.minutes")
+	suite.Nil(err)
+	suite.Equal(21*time.Minute, durationValue)
+	durationValue, err = config.GetDurationE("durations.defaultvalue")
+	suite.Nil(err)
+	suite.Equal(22*time.Second, durationValue)
+
+	_, err = config.GetIntE("xxx")
+	suite.ErrorIs(err, ErrKeyNotFound)
+	_, err = config.GetBoolE("xxx")
+	suite.ErrorIs(err, ErrKeyNotFound)
+	_, err = config.GetDurationE("xxx")
+	suite.ErrorIs(err, ErrKeyNotFound)
+
+	for key, getValue := range map[string]func(string) error{
+		"invalid":               func(key string) error { _, err := config.GetIntE(key); return err },
+		"float":                 func(key string) error { _, err := config.GetIntE(key); return err },
+		"big":                   func(key string) error { _, err := config.GetIntE(key); return err },
+		"key2":                  func(key string) error { _, err := config.GetBoolE(key); return err },
+		"durations.unsupported": func(key string) error { _, err := config.GetDurationE(key); return err },
+	} {
+		err := getValue(key)
+		var conversionErr *ConversionError
+		suite.ErrorAs(err, &conversionErr, key)
+		suite.Equal(key, conversionErr.Key)
+		suite.NotNil(conversionErr.Value)
+		suite.NotErrorIs(err, ErrKeyNotFound)
+	}
+
+	_, err = config.GetIntE("invalid")
+	suite.Equal("unable to convert value abc of key invalid to int: strconv.ParseInt: parsing \"abc\": invalid syntax", err.Error())
+	_, err = config.GetIntE("big")
+	suite.ErrorIs(err, strconv.ErrRange)
+}
+
+func (suite *ConfigTestSuite) TestGetAs() {
+
+	config, err := NewStaticConfigSource(suite.staticConfigForTest() + "\nratio: 0.75\nphase: 1+2i\nbytes: 5368709120\nlimits:\n  read: 100\n  write: 20\nserver:\n  host: localhost\n  timeout: 2m\n").Load()
+	suite.Nil(err)
+
+	for _, cfg := range []Config{config, &wrappedConfig{config}} {
+
+		stringValue, err := GetAs(cfg, "key2", "default")
+		suite.Nil(err)
+		suite.Equal("value2", stringValue)
+
+		intValue, err := GetAs(cfg, "key3", 0)
+		suite.Nil(err)
+		suite.Equal(12345, intValue)
+
+		int64Value, err := GetAs[int64](cfg, "bytes", 0)
+		suite.Nil(err)
+		suite.Equal(int64(5368709120), int64Value)
+
+		floatValue, err := GetAs(cfg, "ratio", 0.0)
+		suite.Nil(err)
+		suite.Equal(0.75, floatValue)
+
+		boolValue, err := GetAs(cfg, "boolval", false)
+		suite.Nil(err)
+		suite.True(boolValue)
+
+		durationValue, err := GetAs(cfg, "durations.minutes", time.Second)
+		suite.Nil(err)
+		suite.Equal(21*time.Minute, durationValue)
+		durationValue, err = GetAs(cfg, "durations.defaultvalue", time.Second)
+		suite.Nil(err)
+		suite.Equal(22*time.Second, durationValue)
+
+		complexValue, err := GetAs[complex128](cfg, "key3", 0)
+		suite.Nil(err)
+		suite.Equal(complex(12345, 0), complexValue)
+		complex64Value, err := GetAs[complex64](cfg, "ratio", 0)
+		suite.Nil(err)
+		suite.Equal(complex64(complex(0.75, 0)), complex64Value)
+		complexValue, err = GetAs[complex128](cfg, "phase", 0)
+		suite.Nil(err)
+		suite.Equal(complex(1, 2), complexValue)
+
+		sliceValue, err := GetAs[[]int64](cfg, "intslice", nil)
+		suite.Nil(err)
+		suite.Equal([]int64{342543545, 3465567, 547657}, sliceValue)
+
+		mapValue, err := GetAs[map[string]uint](cfg, "limits", nil)
+		suite.Nil(err)
+		suite.Equal(map[string]uint{"read": 100, "write": 20}, mapValue)
+
+		sliceOfMaps, err := GetAs[[]map[string]string](cfg, "sliceofmaps", nil)
+		suite.Nil(err)
+		suite.Equal([]map[string]string{{"key1_1": "val1_1", "key1_2": "val1_2"}, {"key2_1": "val2_1", "key2_2": "val2_2"}}, sliceOfMaps)
+
+		type serverConfig struct {
+			Host    string        `mapstructure:"host"`
+			Timeout time.Duration `mapstructure:"timeout"`
+		}
+		structValue, err := GetAs(cfg, "server", serverConfig{})
+		suite.Nil(err)
+		suite.Equal(serverConfig{Host: "localhost", Timeout: 2 * time.Minute}, structValue)
+
+		defaultValue, err := GetAs(cfg, "xxx", 42)
+		suite.Nil(err)
+		suite.Equal(42, defaultValue)
+	}
+
+	var conversionErr *ConversionError
+	intValue, err := GetAs(config, "key2", 1)
+	suite.ErrorAs(err, &conversionErr)
+	suite.Equal("key2", conversionErr.Key)
+	suite.Equal("value2", conversionErr.Value)
+	suite.Equal("int", conversionErr.Type)
+	suite.Equal(1, intValue)
+
+	int32Value, err := GetAs[int32](config, "bytes", 1)
+	suite.ErrorIs(err, strconv.ErrRange)
+	suite.Equal(int32(1), int32Value)
+
+	intValue, err = GetAs(config, "ratio", 1)
+	suite.ErrorAs(err, &conversionErr)
+	suite.Equal(1, intValue)
+
+	durationValue, err := GetAs(config, "durations.unsupported", time.Second)
+	suite.ErrorAs(err, &conversionErr)
+	suite.Equal(time.Second, durationValue)
+
+	_, err = GetAs[[]int](config, "intslice2", nil)
+	suite.ErrorAs(err, &conversionErr)
+	_, err = GetAs[complex64](config, "key2", 0)
+	suite.ErrorAs(err, &conversionErr)
+	_, err = GetAs[[]int](config, "limits", nil)
+	suite.ErrorAs(err, &conversionErr)
+	_, err = GetAs(config, "namespace1", "")
+	suite.ErrorAs(err, &conversionErr)
+
+	_, err = GetAs[int8](config, "key3", 0)
+	suite.ErrorIs(err, strconv.ErrRange)
+	suite.Equal("unable to convert value 12345 of key key3 to int8: value out of range", err.Error())
+
+	// JSON decodes all numbers to float64
+	jsonConfig, err := NewStaticConfigSourceWithFormat(`{"timeout": 30, "ratio": 1.5, "server": {"timeout": 120}}`, "json").Load()
+	suite.Nil(err)
+	durationValue, err = GetAs(jsonConfig, "timeout", time.Second)
+	suite.Nil(err)
+	suite.Equal(30*time.Second, durationValue)
+	suite.Equal(*jsonConfig.GetAsDuration("timeout", nil), durationValue)
+	structValue, err := GetAs(jsonConfig, "server", struct {
+		Timeout time.Duration `mapstructure:"timeout"`
+	}{})
+	suite.Nil(err)
+	suite.Equal(120*time.Second, structValue.Timeout)
+	durationValue, err = GetAs(jsonConfig, "ratio", time.Second)
+	suite.ErrorAs(err, &conversionErr)
+	suite.Equal(time.Second, durationValue)
+}
+
+func (suite *ConfigTestSuite) TestNumericAccessors() {
+
+	config, err := NewStaticConfigSource(suite.staticConfigForTest() + `
+ratio: 0.75
+bytes: 5368709120
+maxuint: 18446744073709551615
+negative: -5
+toobig: 99999999999999999999
+ratios: [0.5, 1, "2.5"]
+sizes: [5368709120, 1024]
+mixed: [1, -1]
+invalid: abc
+`).Load()
+	suite.Nil(err)
+
+	suite.Equal(int64(5368709120), *config.GetAsInt64("bytes", nil))
+	suite.Equal(int64(-5), *config.GetAsInt64("negative", nil))
+	suite.Equal(int64(12345), *config.GetAsInt64("key3", nil))
+	suite.Equal(uint64(5368709120), *config.GetAsUint64("bytes", nil))
+	suite.Equal(uint64(18446744073709551615), *config.GetAsUint64("maxuint", nil))
+	suite.Equal(0.75, *config.GetAsFloat("ratio", nil))
+	suite.Equal(5368709120.0, *config.GetAsFloat("bytes", nil))
+
+	suite.Equal([]int64{5368709120, 1024}, *config.GetAsInt64Slice("sizes", nil))
+	suite.Equal([]int64{342543545, 3465567, 547657}, *config.GetAsInt64Slice("intslice", nil))
+	suite.Equal([]uint64{5368709120, 1024}, *config.GetAsUint64Slice("sizes", nil))
+	suite.Equal([]float64{0.5, 1, 2.5}, *config.GetAsFloatSlice("ratios", nil))
+
+	suite.Nil(config.GetAsInt64("xxx", nil))
+	suite.Equal(int64(1), *config.GetAsInt64("xxx", AsInt64Ptr(1)))
+	suite.Equal(uint64(1), *config.GetAsUint64("xxx", AsUint64Ptr(1)))
+	suite.Equal(1.5, *config.GetAsFloat("xxx", AsFloatPtr(1.5)))
+	suite.Nil(config.GetAsInt64Slice("xxx", nil))
+
+	// Invalid values and overflows return default value
+	suite.Equal(int64(1), *config.GetAsInt64("maxuint", AsInt64Ptr(1)))
+	suite.Equal(int64(1), *config.GetAsInt64("toobig", AsInt64Ptr(1)))
+	suite.Equal(int64(1), *config.GetAsInt64("ratio", AsInt64Ptr(1)))
+	suite.Equal(int64(1), *config.GetAsInt64("invalid", AsInt64Ptr(1)))
+	suite.Equal(uint64(1), *config.GetAsUint64("negative", AsUint64Ptr(1)))
+	suite.Equal(uint64(1), *config.GetAsUint64("toobig", AsUint64Ptr(1)))
+	suite.Equal(1.5, *config.GetAsFloat("invalid", AsFloatPtr(1.5)))
+	suite.Nil(config.GetAsInt64Slice("intslice2", nil))
+	suite.Nil(config.GetAsInt64Slice("ratios", nil))
+	suite.Nil(config.GetAsUint64Slice("mixed", nil))
+	suite.Nil(config.GetAsFloatSlice("key2", nil))
+	suite.Equal([]float64{1}, *config.GetAsFloatSlice("intslice2", &[]float64{1}))
+
+	// JSON numbers are decoded as float64, integers of 2^53 or more may have been rounded
+	jsonConfig, err := NewStaticConfigSourceWithFormat(`{"bytes": 5368709120, "exact": 9007199254740991, "rounded": 9007199254740993, "sizes": [1024, 9007199254740993]}`, "json").Load()
+	suite.Nil(err)
+	suite.Equal(int64(5368709120), *jsonConfig.GetAsInt64("bytes", nil))
+	suite.Equal(uint64(5368709120), *jsonConfig.GetAsUint64("bytes", nil))
+	suite.Equal(int64(9007199254740991), *jsonConfig.GetAsInt64("exact", nil))
+	suite.Nil(jsonConfig.GetAsInt64("rounded", nil))
+	suite.Nil(jsonConfig.GetAsUint64("rounded", nil))
+	suite.Nil(jsonConfig.GetAsInt64Slice("sizes", nil))
+	_, err = GetAs[int64](jsonConfig, "rounded", 0)
+	suite.ErrorIs(err, strconv.ErrRange)
+}
+
+func (suite *ConfigTestSuite) TestEnvConfigSource() {
+
+	suite.T().Setenv("GOCONFIGTEST_KEY2", "value2")
+	suite.T().Setenv("GOCONFIGTEST_NAMESPACE1__KEY1", "value1")
+	suite.T().Setenv("GOCONFIGTEST_KEY3", "12345")
+	suite.T().Setenv("GOCONFIGTEST_BOOLVAL", "true")
+	suite.T().Setenv("GOCONFIGTEST_DURATIONS__SECONDS", "43s")
+	suite.T().Setenv("GOCONFIGTEST_LOG_LEVEL", "debug")
+	suite.T().Setenv("OTHERPREFIX_KEY2", "value3")
+	suite.T().Setenv("GOCONFIGTEST_EMPTY____KEY", "value5")
+
+	config, err := NewEnvConfigSource("GOCONFIGTEST", nil).Load()
+	suite.Nil(err)
+	suite.testGetConfigValuesAsString(config)
+	suite.testGetStructuredConfigValue(config)
+	suite.Equal(12345, *config.GetAsInt("key3", nil))
+	suite.True(*config.GetAsBool("boolval", nil))
+	suite.Equal(43*time.Second, *config.GetAsDuration("durations.seconds", nil))
+	suite.Equal("debug", *config.Get("log_level", nil))
+	suite.Nil(config.Get("otherprefix_key2", nil))
+	suite.False(config.Has("empty"))
+
+	separator := "."
+	suite.T().Setenv("GOCONFIGTEST_NAMESPACE2.KEY1", "value4")
+	config2, err2 := NewEnvConfigSource("GOCONFIGTEST_", &separator).Load()
+	suite.Nil(err2)
+	suite.Equal("value4", *config2.Get("namespace2.key1", nil))
+	suite.Equal("value1", *config2.Get("namespace1__key1", nil))
+}
+
+func (suite *ConfigTestSuite) TestLayeredConfigSource() {
+
+	suite.T().Setenv("GOCONFIGTEST_NAMESPACE1__KEY2", "envvalue")
+	suite.T().Setenv("GOCONFIGTEST_KEY3", "6789")
+
+	defaults := NewStaticConfigSource("namespace1:\n  key1: default1\n  key3: default3\nkey4: default4\n")
+	configFile := "./testconfig.yml"
+	configSource := NewLayeredConfigSource(defaults, NewFileConfigSource(&configFile), NewEnvConfigSource("GOCONFIGTEST", nil))
+
+	config, err := configSource.Load()
+	suite.Nil(err)
+	suite.testGetConfigValuesAsString(config)
+	suite.testGetStructuredConfigValue(config)
+	suite.testGetConfigValuesAsSliceOfMaps(config)
+	suite.testGetConfigValuesAsDuration(config)
+	suite.Equal("envvalue", *config.Get("namespace1.key2", nil))
+	suite.Equal("default3", *config.Get("namespace1.key3", nil))
+	suite.Equal("default4", *config.Get("key4", nil))
+	suite.Equal(6789, *config.GetAsInt("key3", nil))
+
+	notExistingFile := "./notexistingfile.yml"
+	config2, err2 := NewLayeredConfigSource(defaults, NewFileConfigSource(&notExistingFile)).Load()
+	suite.NotNil(err2)
+	suite.Nil(config2)
+}
+
+func (suite *ConfigTestSuite) TestHTTPConfigSource() {
+
+	etag := "\"abc123\""
+	requestCount := 0
+	downloadCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("X-Tenant") != "tenant1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloadCount++
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(suite.staticConfigForTest()))
+	}))
+	defer server.Close()
+
+	configSource := NewHTTPConfigSource(server.URL, map[string]string{"X-Tenant": "tenant1"}, AsStringPtr("secret"))
+	suite.testConfigSource(configSource)
+	suite.testConfigSource(configSource)
+	suite.Equal(2, requestCount)
+	suite.Equal(1, downloadCount)
+
+	config, err := NewHTTPConfigSource(server.URL, nil, nil).Load()
+	suite.NotNil(err)
+	suite.Nil(config)
+
+	config2, err2 := NewHTTPConfigSource("http://127.0.0.1:0/config.yml", nil, nil).Load()
+	suite.NotNil(err2)
+	suite.Nil(config2)
+}
+
+func (suite *ConfigTestSuite) TestSSMConfigSource() {
+
+	suite.setAwsTestCredentials()
+	server := suite.awsStandIn(map[string]func(map[string]any) any{
+		"AmazonSSM.GetParametersByPath": func(request map[string]any) any {
+			suite.Equal("/myapp/prod/", request["Path"])
+			suite.Equal(true, request["WithDecryption"])
+			if request["NextToken"] == nil {
+				return map[string]any{
+					"Parameters": []map[string]any{
+						{"Name": "/myapp/prod/key2", "Type": "String", "Value": "value2"},
+						{"Name": "/myapp/prod/namespace1/key1", "Type": "SecureString", "Value": "value1"},
+					},
+					"NextToken": "page2",
+				}
+			}
+			return map[string]any{
+				"Parameters": []map[string]any{
+					{"Name": "/myapp/prod/key3", "Type": "String", "Value": "12345"},
+					{"Name": "/myapp/prod/intslice", "Type": "StringList", "Value": "342543545,3465567,547657"},
+				},
+			}
+		},
+	})
+	defer server.Close()
+
+	configSource, err := NewSSMConfigSource("/myapp/prod/", AsStringPtr("eu-central-1"), AsStringPtr(server.URL))
+	suite.Nil(err)
+	config, err := configSource.Load()
+	suite.Nil(err)
+	suite.testGetConfigValuesAsString(config)
+	suite.testGetStructuredConfigValue(config)
+	suite.testGetConfigValuesAsInt(config)
+	suite.Equal([]int{342543545, 3465567, 547657}, *config.GetAsIntSlice("intslice", nil))
+
+	configSource2, err := NewSSMConfigSource("/myapp/prod/", AsStringPtr("eu-central-1"), AsStringPtr("http://127.0.0.1:0"))
+	suite.Nil(err)
+	config2, err2 := configSource2.Load()
+	suite.NotNil(err2)
+	suite.Nil(config2)
+}
+
+// setAwsTestCredentials sets static AWS credentials for tests using a local stand-in.
+func (suite *ConfigTestSuite) setAwsTestCredentials() {
+	suite.T().Setenv("AWS_ACCESS_KEY_ID", "test")
+	suite.T().Setenv("AWS_SECRET_ACCESS_KEY", "test")
+	suite.T().Setenv("AWS_EC2_METADATA_DISABLED", "true")
+}
+
+// awsStandIn returns a test server which dispatches AWS JSON protocol requests
+// to passed handlers by their X-Amz-Target header.
+func (suite *ConfigTestSuite) awsStandIn(handlers map[string]func(map[string]any) any) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler, ok := handlers[r.Header.Get("X-Amz-Target")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var request map[string]any
+		suite.Nil(json.NewDecoder(r.Body).Decode(&request))
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		suite.Nil(json.NewEncoder(w).Encode(handler(request)))
+	}))
+}
+
+func (suite *ConfigTestSuite) TestSecretsManagerConfigSource() {
+
+	suite.setAwsTestCredentials()
+	server := suite.awsStandIn(map[string]func(map[string]any) any{
+		"secretsmanager.GetSecretValue": func(request map[string]any) any {
+			switch request["SecretId"] {
+			case "database":
+				return map[string]any{
+					"Name":         "database",
+					"SecretString": `{"host":"localhost","port":5432,"credentials":{"password":"secret"}}`,
+				}
+			case "arn:aws:secretsmanager:eu-central-1:123456789012:secret:apikey-AbCdEf":
+				return map[string]any{
+					"Name":         "apikey",
+					"SecretString": "plain-api-key",
+				}
+			}
+			return map[string]any{}
+		},
+	})
+	defer server.Close()
+
+	secretIds := []string{"database", "arn:aws:secretsmanager:eu-central-1:123456789012:secret:apikey-AbCdEf"}
+	configSource, err := NewSecretsManagerConfigSource(secretIds, AsStringPtr("eu-central-1"), AsStringPtr(server.URL))
+	suite.Nil(err)
+	config, err := configSource.Load()
+	suite.Nil(err)
+	suite.Equal("localhost", *config.Get("secrets.database.host", nil))
+	suite.Equal(5432, *config.GetAsInt("secrets.database.port", nil))
+	suite.Equal("secret", *config.Get("secrets.database.credentials.password", nil))
+	suite.Equal("plain-api-key", *config.Get("secrets.apikey", nil))
+
+	configSource2, err := NewSecretsManagerConfigSource(secretIds, AsStringPtr("eu-central-1"), AsStringPtr("http://127.0.0.1:0"))
+	suite.Nil(err)
+	config2, err2 := configSource2.Load()
+	suite.NotNil(err2)
+	suite.Nil(config2)
+}
+
+func (suite *ConfigTestSuite) TestDirectoryConfigSource() {
+
+	directory := suite.T().TempDir()
+	suite.writeTestFile(filepath.Join(directory, "10-base.yml"), suite.staticConfigForTest())
+	suite.writeTestFile(filepath.Join(directory, "50-override.yaml"), "key3: 6789\nnamespace1:\n  key2: value3\n")
+	suite.writeTestFile(filepath.Join(directory, "90-ignored.json"), "{\"key2\": \"ignored\"}")
+	suite.Nil(os.Mkdir(filepath.Join(directory, "99-dir.yml"), 0755))
+
+	config, err := NewDirectoryConfigSource(&directory).Load()
+	suite.Nil(err)
+	suite.testGetConfigValuesAsString(config)
+	suite.testGetStructuredConfigValue(config)
+	suite.testGetConfigValuesAsDuration(config)
+	suite.Equal(6789, *config.GetAsInt("key3", nil))
+	suite.Equal("value3", *config.Get("namespace1.key2", nil))
+
+	suite.writeTestFile(filepath.Join(directory, "60-invalid.yml"), "key1=val1")
+	config2, err2 := NewDirectoryConfigSource(&directory).Load()
+	suite.NotNil(err2)
+	suite.Contains(err2.Error(), "60-invalid.yml")
+	suite.Nil(config2)
+
+	emptyDirectory := suite.T().TempDir()
+	config3, err3 := NewDirectoryConfigSource(&emptyDirectory).Load()
+	suite.NotNil(err3)
+	suite.Nil(config3)
+
+	notExistingDirectory := filepath.Join(emptyDirectory, "xxx")
+	config4, err4 := NewDirectoryConfigSource(&notExistingDirectory).Load()
+	suite.NotNil(err4)
+	suite.Nil(config4)
+}
+
+// writeTestFile writes passed content to given file.
+func (suite *ConfigTestSuite) writeTestFile(fileName, content string) {
+	suite.Nil(os.WriteFile(fileName, []byte(content), 0644))
+}
+
+func (suite *ConfigTestSuite) TestFSConfigSource() {
+
+	testConfig := []byte(suite.staticConfigForTest())
+	fsys := fstest.MapFS{
+		"config/config.yml":  &fstest.MapFile{Data: testConfig},
+		"configs/custom.yml": &fstest.MapFile{Data: testConfig},
+		"invalid.yml":        &fstest.MapFile{Data: []byte("key1=val1")},
+	}
+
+	suite.testConfigSource(NewFSConfigSource(fsys, nil))
+
+	configFile2 := "configs/custom.yml"
+	suite.testConfigSource(NewFSConfigSource(fsys, &configFile2))
+	suite.testConfigSource(NewFSConfigSource(os.DirFS("."), nil))
+
+	configFile3 := "invalid.yml"
+	config3, err3 := NewFSConfigSource(fsys, &configFile3).Load()
+	suite.NotNil(err3)
+	suite.Contains(err3.Error(), configFile3)
+	suite.Nil(config3)
+
+	configFile4 := "notexistingfile.yml"
+	config4, err4 := NewFSConfigSource(fsys, &configFile4).Load()
+	suite.NotNil(err4)
+	suite.Contains(err4.Error(), configFile4)
+	suite.Nil(config4)
+
+	config5, err5 := NewFSConfigSource(fstest.MapFS{}, nil).Load()
+	suite.NotNil(err5)
+	suite.Nil(config5)
+}
+
+func (suite *ConfigTestSuite) TestFlagConfigSource() {
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.String("key2", "", "")
+	flags.String("namespace1.key1", "", "")
+	flags.Int("key3", 0, "")
+	flags.Bool("boolval", false, "")
+	flags.String("durations.seconds", "", "")
+	flags.Int("server.port", 8080, "")
+	suite.Nil(flags.Parse([]string{"--key2=value2", "--namespace1.key1", "value1", "--key3=12345", "--boolval", "--durations.seconds=43s"}))
+
+	config, err := NewFlagConfigSource(flags).Load()
+	suite.Nil(err)
+	suite.testGetConfigValuesAsString(config)
+	suite.testGetStructuredConfigValue(config)
+	suite.testGetConfigValuesAsInt(config)
+	suite.testGetConfigValuesAsBool(config)
+	suite.Equal(43*time.Second, *config.GetAsDuration("durations.seconds", nil))
+	suite.Nil(config.GetAsInt("server.port", nil))
+
+	pflags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	pflags.String("server.host", "localhost", "")
+	pflags.Int("server.port", 8080, "")
+	pflags.IntSlice("intslice", nil, "")
+	suite.Nil(pflags.Parse([]string{"--server.port=9090", "--intslice=342543545,3465567,547657"}))
+
+	config2, err2 := NewPFlagConfigSource(pflags).Load()
+	suite.Nil(err2)
+	suite.Equal(9090, *config2.GetAsInt("server.port", nil))
+	suite.Equal([]int{342543545, 3465567, 547657}, *config2.GetAsIntSlice("intslice", nil))
+	suite.Nil(config2.Get("server.host", nil))
+}
+
+func (suite *ConfigTestSuite) TestKubernetesVolumeConfigSource() {
+
+	// Mimics volume layout created by Kubernetes for a mounted ConfigMap.
+	directory := suite.T().TempDir()
+	dataDirectory := filepath.Join(directory, "..2026_10_17_08_00_00.123456789")
+	suite.Nil(os.MkdirAll(filepath.Join(dataDirectory, "namespace1"), 0755))
+	suite.writeTestFile(filepath.Join(dataDirectory, "key2"), "value2\n")
+	suite.writeTestFile(filepath.Join(dataDirectory, "key3"), "12345")
+	suite.writeTestFile(filepath.Join(dataDirectory, "boolval"), "true\n")
+	suite.writeTestFile(filepath.Join(dataDirectory, "namespace1", "key1"), "value1")
+	suite.Nil(os.Symlink(filepath.Base(dataDirectory), filepath.Join(directory, "..data")))
+	for _, key := range []string{"key2", "key3", "boolval", "namespace1"} {
+		suite.Nil(os.Symlink(filepath.Join("..data", key), filepath.Join(directory, key)))
+	}
+
+	config, err := NewKubernetesVolumeConfigSource(directory).Load()
+	suite.Nil(err)
+	suite.testGetConfigValuesAsString(config)
+	suite.testGetStructuredConfigValue(config)
+	suite.testGetConfigValuesAsInt(config)
+	suite.testGetConfigValuesAsBool(config)
+	suite.Nil(config.Get("..data", nil))
+
+	suite.Nil(os.Symlink(filepath.Join("..data", "xxx"), filepath.Join(directory, "brokenlink")))
+	config2, err2 := NewKubernetesVolumeConfigSource(directory).Load()
+	suite.NotNil(err2)
+	suite.Nil(config2)
+
+	config3, err3 := NewKubernetesVolumeConfigSource(filepath.Join(directory, "xxx")).Load()
+	suite.NotNil(err3)
+	suite.Nil(config3)
+}
+
+func (suite *ConfigTestSuite) TestDotEnvConfigSource() {
+
+	envFile := filepath.Join(suite.T().TempDir(), ".env")
+	suite.writeTestFile(envFile, `# Local overrides
+KEY2=value2
+export NAMESPACE1__KEY1="value1" # inline comment
+export	KEY3=12345
+BOOLVAL = true
+DURATIONS__SECONDS=43s#no comment
+SINGLE_QUOTED='raw \n value'
+DOUBLE_QUOTED="line1\nline2 \"quoted\""
+MULTILINE="first line
+second line"
+TRAILING="first   
+second  "  
+EMPTY=
+export  EXPORTED=value3
+EXPORT_NAME=value4
+export=value5
+`)
+
+	config, err := NewDotEnvConfigSource(&envFile).Load()
+	suite.Nil(err)
+	suite.testGetConfigValuesAsString(config)
+	suite.testGetStructuredConfigValue(config)
+	suite.testGetConfigValuesAsInt(config)
+	suite.testGetConfigValuesAsBool(config)
+	suite.Equal("43s#no comment", *config.Get("durations.seconds", nil))
+	suite.Equal("raw \\n value", *config.Get("single_quoted", nil))
+	suite.Equal("line1\nline2 \"quoted\"", *config.Get("double_quoted", nil))
+	suite.Equal("first line\nsecond line", *config.Get("multiline", nil))
+	suite.Equal("first   \nsecond  ", *config.Get("trailing", nil))
+	suite.Equal("", *config.Get("empty", nil))
+	suite.Equal("value3", *config.Get("exported", nil))
+	suite.Equal("value4", *config.Get("export_name", nil))
+	suite.Equal("value5", *config.Get("export", nil))
+
+	config4, err4 := NewFileConfigSourceWithFormat(&envFile, "env").Load()
+	suite.Nil(err4)
+	suite.Equal(config.AllSettings(), config4.AllSettings())
+	config5, err5 := NewStaticConfigSourceWithFormat("DATABASE__HOST=localhost", "dotenv").Load()
+	suite.Nil(err5)
+	suite.Equal("localhost", *config5.Get("database.host", nil))
+
+	for _, invalidContent := range []string{"KEY1", "KEY 1=value", "KEY1=\"value", "KEY1=\"value\" xxx", "KEY1='value", "A____B=value", "__A=value", "A__=value"} {
+		suite.writeTestFile(envFile, invalidContent)
+		config2, err2 := NewDotEnvConfigSource(&envFile).Load()
+		suite.NotNil(err2, invalidContent)
+		suite.Nil(config2)
+	}
+
+	notExistingFile := filepath.Join(suite.T().TempDir(), ".env")
+	config3, err3 := NewDotEnvConfigSource(&notExistingFile).Load()
+	suite.NotNil(err3)
+	suite.Nil(config3)
+}
+
+func (suite *ConfigTestSuite) TestConfigSourceFormats() {
+
+	directory := suite.T().TempDir()
+	contents := map[string]string{
+		"json":       "{\"key2\": \"value2\", \"namespace1\": {\"key1\": \"value1\"}, \"key3\": 12345}",
+		"toml":       "key2 = \"value2\"\nkey3 = 12345\n[namespace1]\nkey1 = \"value1\"\n",
+		"properties": "key2 = value2\nnamespace1.key1 = value1\nkey3 = 12345\n",
+		"env":        "KEY2=value2\nNAMESPACE1__KEY1=value1\nKEY3=12345\n",
+	}
+	for format, content := range contents {
+
+		configFile := filepath.Join(directory, "config."+format)
+		suite.writeTestFile(configFile, content)
+		config1, err1 := NewFileConfigSource(&configFile).Load()
+		suite.Nil(err1, format)
+		suite.testGetConfigValuesAsString(config1)
+		suite.testGetStructuredConfigValue(config1)
+		suite.testGetConfigValuesAsInt(config1)
+
+		configFile2 := filepath.Join(directory, format+".txt")
+		suite.writeTestFile(configFile2, content)
+		config2, err2 := NewFileConfigSourceWithFormat(&configFile2, format).Load()
+		suite.Nil(err2, format)
+		suite.testGetConfigValuesAsString(config2)
+
+		config3, err3 := NewStaticConfigSourceWithFormat(content, format).Load()
+		suite.Nil(err3, format)
+		suite.testGetStructuredConfigValue(config3)
+	}
+
+	configFile4 := filepath.Join(directory, "toml.txt")
+	config4, err4 := NewFileConfigSource(&configFile4).Load()
+	suite.NotNil(err4)
+	suite.Nil(config4)
+
+	suite.T().Setenv("HOME", directory)
+	suite.T().Chdir(directory)
+	suite.Nil(os.Mkdir(filepath.Join(directory, "go_config"), 0755))
+	suite.writeTestFile(filepath.Join(directory, "go_config", "config.toml"), contents["toml"])
+	config5, err5 := NewFileConfigSource(nil).Load()
+	suite.Nil(err5)
+	suite.testGetConfigValuesAsString(config5)
+}
+
+func (suite *ConfigTestSuite) TestHTTPConfigSourceFormats() {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/config" {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.Write([]byte("{\"key2\": \"value2\", \"namespace1\": {\"key1\": \"value1\"}}"))
+	}))
+	defer server.Close()
+
+	for _, path := range []string{"/config", "/config.json"} {
+		config, err := NewHTTPConfigSource(server.URL+path, nil, nil).Load()
+		suite.Nil(err, path)
+		suite.testGetConfigValuesAsString(config)
+		suite.testGetStructuredConfigValue(config)
+	}
+}
+
+func (suite *ConfigTestSuite) TestS3ConfigSourceFormats() {
+
+	suite.setAwsTestCredentials()
+	server := suite.s3StandIn(func(w http.ResponseWriter, r *http.Request) []byte {
+		switch r.URL.Path {
+		case "/config-bucket/config":
+			w.Header().Set("Content-Type", "application/json")
+		case "/config-bucket/config.json", "/config-bucket/config.txt":
+		default:
+			return nil
+		}
+		return []byte("{\"key2\": \"value2\", \"namespace1\": {\"key1\": \"value1\"}}")
+	})
+	defer server.Close()
+	suite.T().Setenv("AWS_ENDPOINT_URL_S3", server.URL)
+
+	region := "eu-central-1"
+	for _, key := range []string{"config", "config.json"} {
+		configSource, err := NewS3ConfigSource("config-bucket", key, &region)
+		suite.Nil(err)
+		config, err := configSource.Load()
+		suite.Nil(err, key)
+		suite.testGetConfigValuesAsString(config)
+		suite.testGetStructuredConfigValue(config)
+	}
+
+	configSource, err := NewS3ConfigSourceWithFormat("config-bucket", "config.txt", "json", &region)
+	suite.Nil(err)
+	config, err := configSource.Load()
+	suite.Nil(err)
+	suite.testGetConfigValuesAsString(config)
+}
+
+// s3StandIn returns a test server which responds to S3 requests with content returned by passed handler.
+// A CRC32 checksum is added to each response, if handler returns nil it will respond with 404.
+func (suite *ConfigTestSuite) s3StandIn(handler func(http.ResponseWriter, *http.Request) []byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content := handler(w, r)
+		if content == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		checksum := make([]byte, 4)
+		binary.BigEndian.PutUint32(checksum, crc32.ChecksumIEEE(content))
+		w.Header().Set("X-Amz-Checksum-Crc32", base64.StdEncoding.EncodeToString(checksum))
+		w.Write(content)
+	}))
+}
+
+func (suite *ConfigTestSuite) TestCompressedConfig() {
+
+	content := []byte(suite.staticConfigForTest())
+	var gzipContent bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipContent)
+	gzipWriter.Write(content)
+	gzipWriter.Close()
+	zstdEncoder, err := zstd.NewWriter(nil)
+	suite.Nil(err)
+	zstdContent := zstdEncoder.EncodeAll(content, nil)
+
+	directory := suite.T().TempDir()
+	configFile1 := filepath.Join(directory, "config.yml.gz")
+	suite.writeTestFile(configFile1, gzipContent.String())
+	suite.testConfigSource(NewFileConfigSource(&configFile1))
+
+	configFile2 := filepath.Join(directory, "config.yml.zst")
+	suite.writeTestFile(configFile2, string(zstdContent))
+	suite.testConfigSource(NewFileConfigSource(&configFile2))
+
+	configFile3 := filepath.Join(directory, "invalid.yml.gz")
+	suite.writeTestFile(configFile3, string(gzipContent.Bytes()[:20]))
+	config3, err3 := NewFileConfigSource(&configFile3).Load()
+	suite.NotNil(err3)
+	suite.Nil(config3)
+
+	suite.setAwsTestCredentials()
+	server := suite.s3StandIn(func(w http.ResponseWriter, r *http.Request) []byte {
+		switch r.URL.Path {
+		case "/config-bucket/config.yml":
+			w.Header().Set("Content-Encoding", "gzip")
+			return gzipContent.Bytes()
+		case "/config-bucket/config.yml.zst":
+			return zstdContent
+		}
+		return nil
+	})
+	defer server.Close()
+	suite.T().Setenv("AWS_ENDPOINT_URL_S3", server.URL)
+
+	for _, key := range []string{"config.yml", "config.yml.zst"} {
+		configSource, err := NewS3ConfigSource("config-bucket", key, AsStringPtr("eu-central-1"))
+		suite.Nil(err)
+		suite.testConfigSource(configSource)
+	}
+}
+
+func (suite *ConfigTestSuite) TestDecrypter() {
+
+	RegisterDecrypter(testDecrypter{})
+
+	content := base64.StdEncoding.EncodeToString([]byte(suite.staticConfigForTest()))
+	config, err := NewStaticConfigSource(testDecrypterPrefix + content).Load()
+	suite.Nil(err)
+	suite.testGetConfigValuesAsString(config)
+
+	config2, err2 := NewReaderConfigSource(strings.NewReader(testDecrypterPrefix+content), "yaml").Load()
+	suite.Nil(err2)
+	suite.testGetConfigValuesAsString(config2)
+
+	config3, err3 := NewStaticConfigSource(testDecrypterPrefix + "###").Load()
+	suite.NotNil(err3)
+	suite.Nil(config3)
+
+	config4, err4 := NewStaticConfigSource(suite.staticConfigForTest()).Load()
+	suite.Nil(err4)
+	suite.testGetConfigValuesAsString(config4)
+}
+
+// testDecrypterPrefix marks content encrypted for testDecrypter.
+const testDecrypterPrefix = "base64:"
+
+// testDecrypter decodes base64 encoded YAML content with testDecrypterPrefix.
+type testDecrypter struct{}
+
+func (decrypter testDecrypter) Decrypt(content []byte, configType string) ([]byte, error) {
+	if (configType != "yml" && configType != "yaml") || !bytes.HasPrefix(content, []byte(testDecrypterPrefix)) {
+		return content, nil
+	}
+	return base64.StdEncoding.DecodeString(strings.TrimPrefix(string(content), testDecrypterPrefix))
+}
+
+func (suite *ConfigTestSuite) TestDynamoDBConfigSource() {
+
+	suite.setAwsTestCredentials()
+	server := suite.awsStandIn(map[string]func(map[string]any) any{
+		"DynamoDB_20120810.Scan": func(request map[string]any) any {
+			suite.Equal("config", request["TableName"])
+			if request["ExclusiveStartKey"] == nil {
+				return map[string]any{
+					"Items": []map[string]any{
+						{"key": map[string]any{"S": "namespace1.key1"}, "value": map[string]any{"S": "value1"}},
+						{"key": map[string]any{"S": "key2"}, "value": map[string]any{"S": "value2"}},
+						{"key": map[string]any{"S": "key3"}, "value": map[string]any{"N": "12345"}},
+					},
+					"LastEvaluatedKey": map[string]any{"key": map[string]any{"S": "key3"}},
+				}
+			}
+			return map[string]any{
+				"Items": []map[string]any{
+					{"key": map[string]any{"S": "boolval"}, "value": map[string]any{"BOOL": true}},
+					{"key": map[string]any{"S": "intslice"}, "value": map[string]any{"L": []map[string]any{{"N": "342543545"}, {"N": "3465567"}}}},
+					{"key": map[string]any{"S": "database.port"}, "value": map[string]any{"N": "5432"}},
+					{"key": map[string]any{"S": "database"}, "value": map[string]any{"M": map[string]any{
+						"host":  map[string]any{"S": "localhost"},
+						"ratio": map[string]any{"N": "0.75"},
+					}}},
+				},
+			}
+		},
+		"DynamoDB_20120810.Query": func(request map[string]any) any {
+			suite.Equal("#pk = :pk", request["KeyConditionExpression"])
+			suite.Equal(map[string]any{"#pk": "tenant"}, request["ExpressionAttributeNames"])
+			suite.Equal(map[string]any{":pk": map[string]any{"S": "tenant1"}}, request["ExpressionAttributeValues"])
+			return map[string]any{
+				"Items": []map[string]any{
+					{"tenant": map[string]any{"S": "tenant1"}, "key": map[string]any{"S": "key2"}, "value": map[string]any{"S": "value2"}},
+				},
+			}
+		},
+	})
+	defer server.Close()
+
+	region := AsStringPtr("eu-central-1")
+	configSource, err := NewDynamoDBConfigSource("config", region, AsStringPtr(server.URL))
+	suite.Nil(err)
+	config, err := configSource.Load()
+	suite.Nil(err)
+	suite.testGetConfigValuesAsString(config)
+	suite.testGetStructuredConfigValue(config)
+	suite.testGetConfigValuesAsInt(config)
+	suite.testGetConfigValuesAsBool(config)
+	suite.Equal([]int{342543545, 3465567}, *config.GetAsIntSlice("intslice", nil))
+	suite.Equal("localhost", *config.Get("database.host", nil))
+	suite.Equal("0.75", *config.Get("database.ratio", nil))
+	suite.Equal(5432, *config.GetAsInt("database.port", nil))
+
+	configSource2, err := NewDynamoDBPartitionConfigSource("config", "tenant", "tenant1", region, AsStringPtr(server.URL))
+	suite.Nil(err)
+	config2, err2 := configSource2.Load()
+	suite.Nil(err2)
+	suite.testGetConfigValuesAsString(config2)
+
+	configSource3, err := NewDynamoDBConfigSource("config", region, AsStringPtr("http://127.0.0.1:0"))
+	suite.Nil(err)
+	config3, err3 := configSource3.Load()
+	suite.NotNil(err3)
+	suite.Nil(config3)
+}
+
+func (suite *ConfigTestSuite) TestS3PrefixConfigSource() {
+
+	objects := map[string]string{
+		"configs/myservice/10-base.yml":       suite.staticConfigForTest(),
+		"configs/myservice/50-override.yaml":  "key3: 6789\nnamespace1:\n  key2: value3\n",
+		"configs/myservice/90-ignored.json":   "{\"key2\": \"ignored\"}",
+		"configs/otherservice/10-ignored.yml": "key2: ignored",
+	}
+	suite.setAwsTestCredentials()
+	server := suite.s3StandIn(func(w http.ResponseWriter, r *http.Request) []byte {
+		if strings.TrimSuffix(r.URL.Path, "/") == "/config-bucket" {
+			suite.Equal("configs/myservice/", r.URL.Query().Get("prefix"))
+			if r.URL.Query().Get("continuation-token") == "" {
+				return []byte(`<ListBucketResult><IsTruncated>true</IsTruncated><NextContinuationToken>page2</NextContinuationToken>
+					<Contents><Key>configs/myservice/50-override.yaml</Key></Contents>
+					<Contents><Key>configs/myservice/90-ignored.json</Key></Contents></ListBucketResult>`)
+			}
+			return []byte(`<ListBucketResult><IsTruncated>false</IsTruncated>
+				<Contents><Key>configs/myservice/10-base.yml</Key></Contents></ListBucketResult>`)
+		}
+		content, ok := objects[strings.TrimPrefix(r.URL.Path, "/config-bucket/")]
+		if !ok {
+			return nil
+		}
+		return []byte(content)
+	})
+	defer server.Close()
+	suite.T().Setenv("AWS_ENDPOINT_URL_S3", server.URL)
+
+	configSource, err := NewS3PrefixConfigSource("config-bucket", "configs/myservice/", AsStringPtr("eu-central-1"))
+	suite.Nil(err)
+	config, err := configSource.Load()
+	suite.Nil(err)
+	suite.testGetConfigValuesAsString(config)
+	suite.testGetStructuredConfigValue(config)
+	suite.testGetConfigValuesAsDuration(config)
+	suite.Equal(6789, *config.GetAsInt("key3", nil))
+	suite.Equal("value3", *config.Get("namespace1.key2", nil))
+
+	configSource2, err := NewS3PrefixConfigSource("no-bucket", "configs/myservice/", AsStringPtr("eu-central-1"))
+	suite.Nil(err)
+	config2, err2 := configSource2.Load()
+	suite.NotNil(err2)
+	suite.Nil(config2)
+}
+
+func (suite *ConfigTestSuite) TestS3ConfigSourceVersions() {
+
+	suite.setAwsTestCredentials()
+	server := suite.s3StandIn(func(w http.ResponseWriter, r *http.Request) []byte {
+		if _, ok := r.URL.Query()["versions"]; ok {
+			suite.Equal("config.yml", r.URL.Query().Get("prefix"))
+			return []byte(`<ListVersionsResult><IsTruncated>false</IsTruncated>
+				<Version><Key>config.yml</Key><VersionId>v2</VersionId><ETag>"etag2"</ETag><IsLatest>true</IsLatest><LastModified>2026-10-02T10:00:00.000Z</LastModified><Size>12</Size></Version>
+				<Version><Key>config.yml</Key><VersionId>v1</VersionId><ETag>"etag1"</ETag><IsLatest>false</IsLatest><LastModified>2026-10-01T10:00:00.000Z</LastModified><Size>10</Size></Version>
+				<Version><Key>config.yml.bak</Key><VersionId>v3</VersionId><ETag>"etag3"</ETag><IsLatest>true</IsLatest><LastModified>2026-10-01T10:00:00.000Z</LastModified><Size>10</Size></Version>
+				</ListVersionsResult>`)
+		}
+		switch r.URL.Query().Get("versionId") {
+		case "", "v2":
+			w.Header().Set("X-Amz-Version-Id", "v2")
+			w.Header().Set("ETag", "\"etag2\"")
+			return []byte("key2: value2")
+		case "v1":
+			w.Header().Set("X-Amz-Version-Id", "v1")
+			w.Header().Set("ETag", "\"etag1\"")
+			return []byte("key2: value1")
+		case "v0":
+			w.Header().Set("X-Amz-Version-Id", "v0")
+			return []byte("key2 = \"value0\"")
+		}
+		return nil
+	})
+	defer server.Close()
+	suite.T().Setenv("AWS_ENDPOINT_URL_S3", server.URL)
+	region := AsStringPtr("eu-central-1")
+
+	configSource, err := NewS3ConfigSource("config-bucket", "config.yml", region)
+	suite.Nil(err)
+	s3Source := configSource.(*S3ConfigSource)
+	suite.Nil(s3Source.VersionId())
+	suite.Nil(s3Source.ETag())
+	config, err := s3Source.Load()
+	suite.Nil(err)
+	suite.Equal("value2", *config.Get("key2", nil))
+	suite.Equal("v2", *s3Source.VersionId())
+	suite.Equal("\"etag2\"", *s3Source.ETag())
+
+	versions, err := s3Source.ListVersions()
+	suite.Nil(err)
+	suite.Len(versions, 2)
+	suite.Equal("v2", versions[0].VersionId)
+	suite.True(versions[0].IsLatest)
+	suite.Equal("v1", versions[1].VersionId)
+	suite.Equal("\"etag1\"", versions[1].ETag)
+	suite.Equal(int64(10), versions[1].Size)
+	suite.Equal(time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC), versions[1].LastModified)
+	suite.False(versions[1].IsLatest)
+
+	configSource2, err := NewS3ConfigSourceWithVersion("config-bucket", "config.yml", versions[1].VersionId, region, nil)
+	suite.Nil(err)
+	config2, err2 := configSource2.Load()
+	suite.Nil(err2)
+	suite.Equal("value1", *config2.Get("key2", nil))
+	suite.Equal("v1", *configSource2.(*S3ConfigSource).VersionId())
+
+	// Pinned version with an explicit format
+	configSource4, err := NewS3ConfigSourceWithVersion("config-bucket", "config.yml", "v0", region, AsStringPtr("toml"))
+	suite.Nil(err)
+	config4, err4 := configSource4.Load()
+	suite.Nil(err4)
+	suite.Equal("value0", *config4.Get("key2", nil))
+	suite.Equal("v0", *configSource4.(*S3ConfigSource).VersionId())
+
+	configSource3, err := NewS3ConfigSourceWithVersion("config-bucket", "config.yml", "xxx", region, nil)
+	suite.Nil(err)
+	config3, err3 := configSource3.Load()
+	suite.NotNil(err3)
+	suite.Nil(config3)
+}
+
+func (suite *ConfigTestSuite) TestSQLConfigSource() {
+
+	db, err := sql.Open("configtest", "")
+	suite.Nil(err)
+	defer db.Close()
+
+	configSource := NewSQLConfigSource(db, "SELECT key, value FROM settings WHERE service = ?", "myservice")
+	config, err := configSource.Load()
+	suite.Nil(err)
+	suite.testGetConfigValuesAsString(config)
+	suite.testGetStructuredConfigValue(config)
+	suite.testGetConfigValuesAsInt(config)
+	suite.testGetConfigValuesAsBool(config)
+	suite.Equal(43*time.Second, *config.GetAsDuration("durations.seconds", nil))
+	suite.Nil(config.Get("nullvalue", nil))
+
+	config2, err2 := NewSQLConfigSource(db, "SELECT key, value FROM settings WHERE service = ?", "otherservice").Load()
+	suite.NotNil(err2)
+	suite.Nil(config2)
+
+	config3, err3 := NewSQLConfigSource(db, "SELECT key FROM settings WHERE service = ?", "myservice").Load()
+	suite.NotNil(err3)
+	suite.Nil(config3)
+}
+
+func init() {
+	sql.Register("configtest", testSQLDriver{})
+}
+
+// testSQLDriver is a database/sql driver which returns static config rows for
+// service "myservice" and fails for all other services.
+type testSQLDriver struct{}
+
+func (d testSQLDriver) Open(name string) (driver.Conn, error) {
+	return testSQLConn{}, nil
+}
+
+type testSQLConn struct{}
+
+func (c testSQLConn) Prepare(query string) (driver.Stmt, error) {
+	return testSQLStmt{query: query}, nil
+}
+
+func (c testSQLConn) Close() error {
+	return nil
+}
+
+func (c testSQLConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions not supported")
+}
+
+type testSQLStmt struct {
+	query string
+}
+
+func (s testSQLStmt) Close() error {
+	return nil
+}
+
+func (s testSQLStmt) NumInput() int {
+	return strings.Count(s.query, "?")
+}
+
+func (s testSQLStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec not supported")
+}
+
+func (s testSQLStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if len(args) != 1 || args[0] != "myservice" {
+		return nil, errors.New("unknown service")
+	}
+	rows := &testSQLRows{values: [][]driver.Value{
+		{"key2", "value2"},
+		{"key3", int64(12345)},
+		{"namespace1.key1", "value1"},
+		{"boolval", "true"},
+		{"durations.seconds", "43s"},
+		{"nullvalue", nil},
+	}}
+	if strings.HasPrefix(s.query, "SELECT key FROM") {
+		rows.columns = []string{"key"}
+		for idx := range rows.values {
+			rows.values[idx] = rows.values[idx][:1]
+		}
+		return rows, nil
+	}
+	rows.columns = []string{"key", "value"}
+	return rows, nil
+}
+
+type testSQLRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *testSQLRows) Columns() []string {
+	return r.columns
+}
+
+func (r *testSQLRows) Close() error {
+	return nil
+}
+
+func (r *testSQLRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func (suite *ConfigTestSuite) TestGitConfigSource() {
+
+	directory := suite.T().TempDir()
+	repository, err := git.PlainInit(directory, false)
+	suite.Nil(err)
+	worktree, err := repository.Worktree()
+	suite.Nil(err)
+	signature := &object.Signature{Name: "Test", Email: "test@example.com", When: time.Now()}
+	commit := func(fileName, content string) plumbing.Hash {
+		suite.Nil(os.MkdirAll(filepath.Dir(filepath.Join(directory, fileName)), 0755))
+		suite.writeTestFile(filepath.Join(directory, fileName), content)
+		_, err := worktree.Add(fileName)
+		suite.Nil(err)
+		hash, err := worktree.Commit("Update "+fileName, &git.CommitOptions{Author: signature})
+		suite.Nil(err)
+		return hash
+	}
+
+	commit1 := commit("configs/app.yml", suite.staticConfigForTest())
+	_, err = repository.CreateTag("v1.0.0", commit1, &git.CreateTagOptions{Tagger: signature, Message: "v1.0.0"})
+	suite.Nil(err)
+	commit2 := commit("configs/app.yml", "key2: value3\n")
+	commit3 := commit("configs/app.json", "{\"key2\": \"value4\"}")
+
+	for _, ref := range []string{"v1.0.0", commit1.String(), commit1.String()[:8]} {
+		configSource := NewGitConfigSource(directory, ref, "configs/app.yml")
+		suite.testConfigSource(configSource)
+		suite.Equal(commit1.String(), *configSource.(*GitConfigSource).Commit())
+	}
+
+	for _, ref := range []string{"", "master", "HEAD~1"} {
+		configSource := NewGitConfigSource(directory, ref, "configs/app.yml")
+		suite.Nil(configSource.(*GitConfigSource).Commit())
+		config, err := configSource.Load()
+		suite.Nil(err, ref)
+		suite.Equal("value3", *config.Get("key2", nil))
+	}
+	configSource := NewGitConfigSource(directory, "HEAD~1", "configs/app.yml")
+	_, err = configSource.Load()
+	suite.Nil(err)
+	suite.Equal(commit2.String(), *configSource.(*GitConfigSource).Commit())
+
+	_, err = repository.CreateTag("latest", commit3, nil)
+	suite.Nil(err)
+	suite.Nil(repository.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("feature"), commit2)))
+
+	// Clone from URL, only latest commit of a branch or tag will be fetched.
+	url := "file://" + directory
+	for ref, expectedCommit := range map[string]plumbing.Hash{"": commit3, "master": commit3, "feature": commit2, "v1.0.0": commit1, "latest": commit3} {
+		source := NewGitConfigSource(url, ref, "configs/app.yml").(*GitConfigSource)
+		clonedRepository, revision, err := source.openRepository()
+		suite.Nil(err, ref)
+		shallowCommits, err := clonedRepository.Storer.Shallow()
+		suite.Nil(err)
+		suite.Equal([]plumbing.Hash{expectedCommit}, shallowCommits, ref)
+		branches, err := clonedRepository.Branches()
+		suite.Nil(err)
+		branchCount := 0
+		suite.Nil(branches.ForEach(func(*plumbing.Reference) error { branchCount++; return nil }))
+		suite.LessOrEqual(branchCount, 1, ref)
+		hash, err := clonedRepository.ResolveRevision(revision)
+		suite.Nil(err, ref)
+		suite.Equal(expectedCommit, *hash, ref)
+	}
+
+	configSource2 := NewGitConfigSource(url, "master", "configs/app.json")
+	config2, err2 := configSource2.Load()
+	suite.Nil(err2)
+	suite.Equal("value4", *config2.Get("key2", nil))
+	suite.Equal(commit3.String(), *configSource2.(*GitConfigSource).Commit())
+	suite.testConfigSource(NewGitConfigSource(url, "v1.0.0", "configs/app.yml"))
+
+	// Commits and revisions other than branches or tags require a full clone.
+	for ref, expectedCommit := range map[string]plumbing.Hash{commit1.String(): commit1, commit2.String()[:8]: commit2, "HEAD~1": commit2} {
+		source := NewGitConfigSource(url, ref, "configs/app.yml").(*GitConfigSource)
+		clonedRepository, _, err := source.openRepository()
+		suite.Nil(err, ref)
+		shallowCommits, err := clonedRepository.Storer.Shallow()
+		suite.Nil(err)
+		suite.Empty(shallowCommits, ref)
+		_, err = source.Load()
+		suite.Nil(err, ref)
+		suite.Equal(expectedCommit.String(), *source.Commit(), ref)
+	}
+
+	for _, invalidSource := range []ConfigSource{
+		NewGitConfigSource(directory, "notexistingbranch", "configs/app.yml"),
+		NewGitConfigSource(directory, "master", "configs/notexistingfile.yml"),
+		NewGitConfigSource(filepath.Join(directory, "xxx"), "master", "configs/app.yml"),
+		NewGitConfigSource(url, "notexistingbranch", "configs/app.yml"),
+		NewGitConfigSource("file://"+filepath.Join(directory, "xxx"), "master", "configs/app.yml"),
+	} {
+		config, err := invalidSource.Load()
+		suite.NotNil(err)
+		suite.Nil(config)
+		suite.Nil(invalidSource.(*GitConfigSource).Commit())
+	}
+}
+
+func (suite *ConfigTestSuite) TestVaultConfigSource() {
+
+	secrets := map[string]map[string]any{
+		"/v1/secret/data/myapp/config":   {"key2": "value2", "namespace1": map[string]any{"key1": "value1"}},
+		"/v1/secret/data/myapp/database": {"user": "admin", "password": "secret"},
+		"/v1/kv/data/myapp/overrides":    {"key2": "value3"},
+	}
+	loginCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/v1/auth/approle/login" {
+			var login map[string]string
+			suite.Nil(json.NewDecoder(r.Body).Decode(&login))
+			if login["role_id"] != "role1" || login["secret_id"] != "secret1" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			loginCount++
+			json.NewEncoder(w).Encode(map[string]any{"auth": map[string]any{"client_token": "approle-token"}})
+			return
+		}
+		if token := r.Header.Get("X-Vault-Token"); token != "root-token" && token != "approle-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		data, ok := secrets[r.URL.Path]
+		if r.Method != http.MethodGet || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]any{"data": data, "metadata": map[string]any{"version": 1}},
+		})
+	}))
+	defer server.Close()
+
+	vaultSecrets := []VaultSecret{
+		{Mount: "secret", Path: "myapp/config"},
+		{Mount: "secret", Path: "myapp/database", KeyPrefix: "database.credentials"},
+	}
+	config, err := NewVaultConfigSource(server.URL, "root-token", vaultSecrets).Load()
+	suite.Nil(err)
+	suite.testGetConfigValuesAsString(config)
+	suite.testGetStructuredConfigValue(config)
+	suite.Equal("admin", *config.Get("database.credentials.user", nil))
+
+	configSource := NewVaultAppRoleConfigSource(server.URL, "role1", "secret1", append(vaultSecrets, VaultSecret{Mount: "/kv/", Path: "/myapp/overrides"}))
+	config2, err2 := configSource.Load()
+	suite.Nil(err2)
+	suite.Equal("value3", *config2.Get("key2", nil))
+	suite.Equal("value1", *config2.Get("namespace1.key1", nil))
+	suite.Equal("admin", *config2.Get("database.credentials.user", nil))
+	suite.Equal("secret", *config2.Get("database.credentials.password", nil))
+	suite.Equal(1, loginCount)
+
+	for _, invalidSource := range []ConfigSource{
+		NewVaultConfigSource(server.URL, "invalid-token", vaultSecrets),
+		NewVaultConfigSource(server.URL, "root-token", []VaultSecret{{Mount: "secret", Path: "notexisting"}}),
+		NewVaultAppRoleConfigSource(server.URL, "role1", "invalid", vaultSecrets),
+		NewVaultConfigSource("http://127.0.0.1:0", "root-token", vaultSecrets),
+	} {
+		config, err := invalidSource.Load()
+		suite.NotNil(err)
+		suite.Nil(config)
+	}
+}
+
+func (suite *ConfigTestSuite) TestReaderConfigSource() {
+
+	configSource := NewReaderConfigSource(strings.NewReader(suite.staticConfigForTest()), "")
+	suite.testConfigSource(configSource)
+	suite.testConfigSource(configSource)
+
+	config, err := NewReaderConfigSource(strings.NewReader("{\"key2\": \"value2\", \"namespace1\": {\"key1\": \"value1\"}}"), "json").Load()
+	suite.Nil(err)
+	suite.testGetConfigValuesAsString(config)
+	suite.testGetStructuredConfigValue(config)
+
+	var gzipContent bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipContent)
+	gzipWriter.Write([]byte(suite.staticConfigForTest()))
+	gzipWriter.Close()
+	suite.testConfigSource(NewReaderConfigSource(&gzipContent, "yaml"))
+
+	config2, err2 := NewReaderConfigSource(iotest.ErrReader(errors.New("read error")), "yaml").Load()
+	suite.NotNil(err2)
+	suite.Nil(config2)
+
+	config3, err3 := NewReaderConfigSource(strings.NewReader("key2: value2"), "json").Load()
+	suite.NotNil(err3)
+	suite.Nil(config3)
+}
+
+// wrappedConfig hides the underlying ViperConfig to test generic access to config values.
+type wrappedConfig struct {
+	Config
+}
@@ -0,0 +1,41 @@
+package config
+
+import "sync"
+
+// Decrypter decrypts encrypted config content before it's parsed.
+type Decrypter interface {
+
+	// Decrypt returns decrypted content of passed config in given format, e.g. yaml or json.
+	// Content which isn't encrypted or not supported by this decrypter has to be returned unchanged.
+	Decrypt(content []byte, configType string) ([]byte, error)
+}
+
+var (
+	decryptersMutex sync.RWMutex
+	decrypters      []Decrypter
+)
+
+// RegisterDecrypter adds passed decrypter which will be applied to content of all config sources
+// before it's parsed. Decrypters are applied in order of registration. It's usually called in an init
+// function of a package which provides a decrypter, e.g. github.com/tommzn/go-config/sops.
+func RegisterDecrypter(decrypter Decrypter) {
+
+	decryptersMutex.Lock()
+	defer decryptersMutex.Unlock()
+	decrypters = append(decrypters, decrypter)
+}
+
+// decryptContent passes given config content to all registered decrypters and returns the decrypted content.
+func decryptContent(content []byte, configType string) ([]byte, error) {
+
+	decryptersMutex.RLock()
+	defer decryptersMutex.RUnlock()
+
+	var err error
+	for _, decrypter := range decrypters {
+		if content, err = decrypter.Decrypt(content, configType); err != nil {
+			return nil, err
+		}
+	}
+	return content, nil
+}
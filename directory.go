@@ -0,0 +1,71 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultConfigDirectory is used if no directory has been passed to a directory config source.
+const defaultConfigDirectory = "/etc/go_config/conf.d/"
+
+// DirectoryConfigSource reads all YAML config files in a directory using viper config.
+type DirectoryConfigSource struct {
+	directory *string
+}
+
+// NewDirectoryConfigSource returns a new config source for all config files in given directory.
+// If you don't pass a directory, config files in "/etc/go_config/conf.d/" will be used.
+func NewDirectoryConfigSource(directory *string) ConfigSource {
+	return &DirectoryConfigSource{directory: directory}
+}
+
+// Load reads all files with extension .yml or .yaml in lexical order and deep merges them
+// into a single ViperConfig. Values of a file will overwrite values of all previous files.
+// Returns with an error if there's no config file in the directory.
+func (source *DirectoryConfigSource) Load() (Config, error) {
+
+	directory := defaultConfigDirectory
+	if source.directory != nil {
+		directory = *source.directory
+	}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]any)
+	fileCount := 0
+	for _, entry := range entries {
+
+		if entry.IsDir() || !isYamlFile(entry.Name()) {
+			continue
+		}
+
+		configFile := filepath.Join(directory, entry.Name())
+		fileContent, err := os.ReadFile(configFile)
+		if err != nil {
+			return nil, err
+		}
+		config, err := newViperConfigFromReader(bytes.NewReader(fileContent), "yaml")
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", configFile, err)
+		}
+		mergeSettings(settings, config.AllSettings())
+		fileCount++
+	}
+
+	if fileCount == 0 {
+		return nil, fmt.Errorf("no config files found in %s", directory)
+	}
+	return newViperConfigFromMap(settings)
+}
+
+// isYamlFile returns true if passed file name has a YAML extension.
+func isYamlFile(fileName string) bool {
+	extension := strings.ToLower(filepath.Ext(fileName))
+	return extension == ".yml" || extension == ".yaml"
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// defaultDotEnvFile is used if no file has been passed to a dotenv config source.
+const defaultDotEnvFile = ".env"
+
+// DotEnvConfigSource reads config from a dotenv (.env) file.
+type DotEnvConfigSource struct {
+	envFile *string
+}
+
+// NewDotEnvConfigSource returns a new config source for given dotenv file.
+// If you don't pass a file, ".env" in current directory will be used.
+func NewDotEnvConfigSource(envFile *string) ConfigSource {
+	return &DotEnvConfigSource{envFile: envFile}
+}
+
+// Load parses the dotenv file and returns its values as ViperConfig. Variable names are
+// split into nested keys by "__" and converted to lower case, e.g. DATABASE__HOST will be
+// available as database.host.
+// Supported syntax:
+// - comments, lines starting with "#" and "#" after an unquoted value
+// - optional "export " prefix
+// - single quoted values, used as is
+// - double quoted values, with escape sequences \n, \r, \t, \" and \\
+// - line breaks in quoted values
+func (source *DotEnvConfigSource) Load() (Config, error) {
+
+	envFile := defaultDotEnvFile
+	if source.envFile != nil {
+		envFile = *source.envFile
+	}
+
+	fileContent, err := os.ReadFile(envFile)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]any)
+	if err := (dotEnvDecoder{}).Decode(fileContent, settings); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", envFile, err)
+	}
+	return newViperConfigFromMap(settings)
+}
+
+// dotEnvDecoder decodes dotenv content. It's used for config types env and dotenv, so all sources
+// split variable names into nested keys the same way as DotEnvConfigSource does.
+type dotEnvDecoder struct{}
+
+// Decode parses passed dotenv content and writes its variables to given config tree.
+func (decoder dotEnvDecoder) Decode(content []byte, settings map[string]any) error {
+
+	values, err := parseDotEnv(string(content))
+	if err != nil {
+		return err
+	}
+	for _, value := range values {
+		setNestedValue(settings, value.key, value.value)
+	}
+	return nil
+}
+
+// dotEnvValue is a single variable defined in a dotenv file.
+type dotEnvValue struct {
+	key   []string
+	value string
+}
+
+// parseDotEnv returns all variables defined in passed dotenv content in order of their definition.
+func parseDotEnv(content string) ([]dotEnvValue, error) {
+
+	var values []dotEnvValue
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for lineNumber := 0; lineNumber < len(lines); lineNumber++ {
+
+		// Only leading whitespaces are removed, trailing ones may belong to a multiline quoted value.
+		line := strings.TrimLeft(lines[lineNumber], " \t")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if remaining, ok := strings.CutPrefix(line, "export"); ok && strings.IndexAny(remaining, " \t") == 0 {
+			line = strings.TrimLeft(remaining, " \t")
+		}
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("invalid definition at line %d", lineNumber+1)
+		}
+		key, ok := envKey(name, defaultEnvSeparator)
+		if !ok {
+			return nil, fmt.Errorf("invalid variable name %s at line %d", name, lineNumber+1)
+		}
+
+		value = strings.TrimLeft(value, " \t")
+		if value == "" || (value[0] != '"' && value[0] != '\'') {
+			values = append(values, dotEnvValue{key: key, value: unquotedDotEnvValue(value)})
+			continue
+		}
+
+		// Quoted values can span multiple lines, so remaining lines are parsed until closing quote.
+		startLine := lineNumber
+		quote := value[0]
+		remaining := value[1:]
+		var builder strings.Builder
+		for {
+			end, err := parseQuotedDotEnvValue(remaining, quote, &builder)
+			if err != nil {
+				return nil, fmt.Errorf("%w at line %d", err, lineNumber+1)
+			}
+			if end >= 0 {
+				remaining = strings.TrimSpace(remaining[end+1:])
+				break
+			}
+			lineNumber++
+			if lineNumber >= len(lines) {
+				return nil, fmt.Errorf("missing closing quote for value at line %d", startLine+1)
+			}
+			builder.WriteByte('\n')
+			remaining = lines[lineNumber]
+		}
+		if remaining != "" && !strings.HasPrefix(remaining, "#") {
+			return nil, fmt.Errorf("unexpected characters after quoted value at line %d", lineNumber+1)
+		}
+		values = append(values, dotEnvValue{key: key, value: builder.String()})
+	}
+	return values, nil
+}
+
+// parseQuotedDotEnvValue writes content of a quoted value to passed builder until closing quote
+// is found and returns its position. Returns -1 if there's no closing quote in passed text.
+func parseQuotedDotEnvValue(text string, quote byte, builder *strings.Builder) (int, error) {
+
+	for idx := 0; idx < len(text); idx++ {
+
+		char := text[idx]
+		if char == quote {
+			return idx, nil
+		}
+		if char != '\\' || quote != '"' {
+			builder.WriteByte(char)
+			continue
+		}
+
+		idx++
+		if idx >= len(text) {
+			return -1, errors.New("invalid escape sequence")
+		}
+		switch text[idx] {
+		case 'n':
+			builder.WriteByte('\n')
+		case 'r':
+			builder.WriteByte('\r')
+		case 't':
+			builder.WriteByte('\t')
+		case '"', '\\', '$':
+			builder.WriteByte(text[idx])
+		default:
+			builder.WriteByte('\\')
+			builder.WriteByte(text[idx])
+		}
+	}
+	return -1, nil
+}
+
+// unquotedDotEnvValue removes inline comments and surrounding whitespaces from passed value.
+func unquotedDotEnvValue(value string) string {
+
+	for idx := 0; idx < len(value); idx++ {
+		if value[idx] == '#' && (idx == 0 || value[idx-1] == ' ' || value[idx-1] == '\t') {
+			value = value[:idx]
+			break
+		}
+	}
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,219 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	// dynamoDBKeyAttribute is the item attribute which contains the config key path.
+	dynamoDBKeyAttribute = "key"
+
+	// dynamoDBValueAttribute is the item attribute which contains the config value.
+	dynamoDBValueAttribute = "value"
+)
+
+// DynamoDBConfigSource loads config from items in an AWS DynamoDB table.
+type DynamoDBConfigSource struct {
+
+	// AWS config for DynamoDB access.
+	cfg aws.Config
+
+	// Name of the table.
+	table string
+
+	// Optional partition key attribute, if only items of a single partition should be loaded.
+	partitionKey *string
+
+	// Value of the partition key.
+	partitionValue string
+
+	// Optional endpoint to use instead of default DynamoDB endpoint.
+	endpoint *string
+}
+
+// NewDynamoDBConfigSource returns a new config source which loads all items of given table.
+// If region is empty it will try to get current AWS region from environment variable AWS_REGION.
+// An endpoint can be passed to use a different endpoint than the default DynamoDB endpoint,
+// e.g. DynamoDB Local.
+func NewDynamoDBConfigSource(table string, region, endpoint *string) (ConfigSource, error) {
+
+	cfg, err := newAwsConfig(region)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DynamoDBConfigSource{
+		cfg:      cfg,
+		table:    table,
+		endpoint: endpoint,
+	}, nil
+}
+
+// NewDynamoDBPartitionConfigSource returns a new config source which loads all items of given table
+// with passed value for partition key attribute, e.g. all items of a single tenant.
+// If region is empty it will try to get current AWS region from environment variable AWS_REGION.
+// An endpoint can be passed to use a different endpoint than the default DynamoDB endpoint,
+// e.g. DynamoDB Local.
+func NewDynamoDBPartitionConfigSource(table, partitionKey, partitionValue string, region, endpoint *string) (ConfigSource, error) {
+
+	cfg, err := newAwsConfig(region)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DynamoDBConfigSource{
+		cfg:            cfg,
+		table:          table,
+		partitionKey:   aws.String(partitionKey),
+		partitionValue: partitionValue,
+		endpoint:       endpoint,
+	}, nil
+}
+
+// Load reads all items from the table and pass them to a ViperConfig. Each item has to contain
+// a config key path, e.g. database.host, in attribute "key" and a config value in attribute "value".
+// Values can be scalars, lists or maps. Maps are available as nested keys below the key of the item.
+func (source *DynamoDBConfigSource) Load() (Config, error) {
+
+	items, err := source.readItems()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]any)
+	for _, item := range items {
+
+		key, ok := item[dynamoDBKeyAttribute].(*types.AttributeValueMemberS)
+		if !ok || key.Value == "" {
+			return nil, fmt.Errorf("item without string attribute %s in table %s", dynamoDBKeyAttribute, source.table)
+		}
+		value, ok := item[dynamoDBValueAttribute]
+		if !ok {
+			return nil, fmt.Errorf("item %s without attribute %s in table %s", key.Value, dynamoDBValueAttribute, source.table)
+		}
+		entries[key.Value] = dynamoDBValue(value)
+	}
+
+	// Apply items ordered by key, so values of nested keys are merged into maps of parent keys.
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	settings := make(map[string]any)
+	for _, key := range keys {
+		itemSettings := make(map[string]any)
+		setNestedValue(itemSettings, strings.Split(key, "."), entries[key])
+		mergeSettings(settings, itemSettings)
+	}
+	return newViperConfigFromMap(settings)
+}
+
+// readItems scans the table or queries items of a partition, if a partition key has been set.
+func (source *DynamoDBConfigSource) readItems() ([]map[string]types.AttributeValue, error) {
+
+	client := dynamodb.NewFromConfig(source.cfg, func(options *dynamodb.Options) {
+		if source.endpoint != nil {
+			options.BaseEndpoint = source.endpoint
+		}
+	})
+
+	var items []map[string]types.AttributeValue
+	if source.partitionKey != nil {
+		paginator := dynamodb.NewQueryPaginator(client, &dynamodb.QueryInput{
+			TableName:                 aws.String(source.table),
+			KeyConditionExpression:    aws.String("#pk = :pk"),
+			ExpressionAttributeNames:  map[string]string{"#pk": *source.partitionKey},
+			ExpressionAttributeValues: map[string]types.AttributeValue{":pk": &types.AttributeValueMemberS{Value: source.partitionValue}},
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(context.TODO())
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, output.Items...)
+		}
+		return items, nil
+	}
+
+	paginator := dynamodb.NewScanPaginator(client, &dynamodb.ScanInput{
+		TableName: aws.String(source.table),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, output.Items...)
+	}
+	return items, nil
+}
+
+// dynamoDBValue converts passed attribute value to a config value.
+func dynamoDBValue(attributeValue types.AttributeValue) any {
+
+	switch value := attributeValue.(type) {
+	case *types.AttributeValueMemberS:
+		return value.Value
+	case *types.AttributeValueMemberN:
+		return dynamoDBNumber(value.Value)
+	case *types.AttributeValueMemberBOOL:
+		return value.Value
+	case *types.AttributeValueMemberB:
+		return string(value.Value)
+	case *types.AttributeValueMemberM:
+		values := make(map[string]any)
+		for key, mapValue := range value.Value {
+			values[key] = dynamoDBValue(mapValue)
+		}
+		return values
+	case *types.AttributeValueMemberL:
+		values := make([]any, 0, len(value.Value))
+		for _, listValue := range value.Value {
+			values = append(values, dynamoDBValue(listValue))
+		}
+		return values
+	case *types.AttributeValueMemberSS:
+		values := make([]any, 0, len(value.Value))
+		for _, setValue := range value.Value {
+			values = append(values, setValue)
+		}
+		return values
+	case *types.AttributeValueMemberNS:
+		values := make([]any, 0, len(value.Value))
+		for _, setValue := range value.Value {
+			values = append(values, dynamoDBNumber(setValue))
+		}
+		return values
+	case *types.AttributeValueMemberBS:
+		values := make([]any, 0, len(value.Value))
+		for _, setValue := range value.Value {
+			values = append(values, string(setValue))
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// dynamoDBNumber converts passed DynamoDB number to int64 or float64. Returns number as string
+// if it can't be represented by one of these types.
+func dynamoDBNumber(number string) any {
+
+	if intValue, err := strconv.ParseInt(number, 10, 64); err == nil {
+		return intValue
+	}
+	if floatValue, err := strconv.ParseFloat(number, 64); err == nil {
+		return floatValue
+	}
+	return number
+}
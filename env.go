@@ -0,0 +1,84 @@
+package config
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// defaultEnvSeparator is used to split environment variable names into nested config keys.
+const defaultEnvSeparator = "__"
+
+// EnvConfigSource creates a config from environment variables.
+type EnvConfigSource struct {
+
+	// Prefix environment variables have to start with, e.g. MYAPP.
+	prefix string
+
+	// Separator to split variable names into nested config keys.
+	separator string
+}
+
+// NewEnvConfigSource returns a config source for environment variables with given prefix.
+// Only variables starting with prefix followed by an underscore will be used and prefix
+// is removed from config keys. If prefix is empty all environment variables are used.
+// Variable names are split into nested keys by passed separator, default is "__".
+// Example: MYAPP_DATABASE__HOST will be available as database.host for prefix MYAPP.
+func NewEnvConfigSource(prefix string, separator *string) ConfigSource {
+
+	envSeparator := defaultEnvSeparator
+	if separator != nil && *separator != "" {
+		envSeparator = *separator
+	}
+	return &EnvConfigSource{prefix: prefix, separator: envSeparator}
+}
+
+// Load reads all matching environment variables and returns them as ViperConfig.
+// Config keys are lower case.
+func (source *EnvConfigSource) Load() (Config, error) {
+
+	environ := os.Environ()
+	sort.Strings(environ)
+
+	settings := make(map[string]any)
+	for _, entry := range environ {
+
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		if key, ok := source.configKey(name); ok {
+			setNestedValue(settings, key, value)
+		}
+	}
+	return newViperConfigFromMap(settings)
+}
+
+// configKey removes prefix from passed variable name and splits it into a nested key.
+// Returns false if variable doesn't match prefix of this source.
+func (source *EnvConfigSource) configKey(name string) ([]string, bool) {
+
+	if source.prefix != "" {
+		prefix := strings.ToUpper(strings.TrimSuffix(source.prefix, "_")) + "_"
+		if !strings.HasPrefix(strings.ToUpper(name), prefix) {
+			return nil, false
+		}
+		name = name[len(prefix):]
+	}
+
+	return envKey(name, source.separator)
+}
+
+// envKey splits passed variable name by given separator into a nested key in lower case.
+// Returns false if the name contains an empty key element, e.g. A____B.
+func envKey(name, separator string) ([]string, bool) {
+
+	var key []string
+	for _, part := range strings.Split(strings.ToLower(name), separator) {
+		if part == "" {
+			return nil, false
+		}
+		key = append(key, part)
+	}
+	return key, true
+}
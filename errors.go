@@ -0,0 +1,45 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrKeyNotFound is returned by strict accessors if there's no config value for a key.
+var ErrKeyNotFound = errors.New("config key not found")
+
+// ConversionError is returned by strict accessors if a config value can not be
+// converted to the requested type.
+type ConversionError struct {
+
+	// Key of the config value.
+	Key string
+
+	// Raw config value.
+	Value any
+
+	// Name of the type the value should be converted to, e.g. int or bool.
+	Type string
+
+	// Optional cause, e.g. a strconv.NumError.
+	Err error
+}
+
+// Error returns a message which contains key, raw value and requested type.
+func (err *ConversionError) Error() string {
+	message := fmt.Sprintf("unable to convert value %v of key %s to %s", err.Value, err.Key, err.Type)
+	if err.Err != nil {
+		message += ": " + err.Err.Error()
+	}
+	return message
+}
+
+// Unwrap returns the cause of this conversion error.
+func (err *ConversionError) Unwrap() error {
+	return err.Err
+}
+
+// keyNotFoundError returns ErrKeyNotFound wrapped with passed key.
+func keyNotFoundError(key string) error {
+	return fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+}
@@ -2,14 +2,23 @@ package config
 
 import (
 	"bytes"
+	"fmt"
 	"os"
+	"path/filepath"
 
+	"github.com/spf13/afero"
 	"github.com/spf13/viper"
 )
 
-// FileConfigSource reads a config file in YAML format using viper config.
+// fileConfigSearchPaths are directories searched for a default config file.
+var fileConfigSearchPaths = []string{"./", "$HOME/", "$HOME/go_config/", "/etc/go_config/"}
+
+// FileConfigSource reads a config file using viper config.
 type FileConfigSource struct {
 	configFile *string
+
+	// Optional format of the config file, e.g. yaml or json.
+	configType string
 }
 
 // NewFileConfigSource returns a new config source for given file.
@@ -20,36 +29,82 @@ func NewFileConfigSource(configFile *string) ConfigSource {
 	return &FileConfigSource{configFile: configFile}
 }
 
+// NewFileConfigSourceWithFormat returns a new config source for given file in passed format,
+// e.g. yaml, json, toml, properties or dotenv. Format will be used regardless of file extension.
+func NewFileConfigSourceWithFormat(configFile *string, format string) ConfigSource {
+	return &FileConfigSource{configFile: configFile, configType: format}
+}
+
 // Load reads a config file and returns a ViperConfig.
 // It uses the config file you've set during creating this source or
-// it tries to find a file names config.yml or testconfig.yml in following locations.
+// it tries to find a file named config with a supported extension, e.g. config.yml,
+// in following locations.
 // - loca directory, "./"
 // - user home, "$HOME/"
 // - user home at go_config dir, "$HOME/go_config/"
 // - at "/etc/go_config/"
+// Format of the config file is detected by its extension, if no explicit format has been set.
+// Files without a known extension are expected in YAML format or the format set by SetViperConfigType.
+// Files compressed with gzip or zstd are decompressed before parsing.
 func (source *FileConfigSource) Load() (Config, error) {
 
+	configFile, err := source.configFileName()
+	if err != nil {
+		return nil, err
+	}
+
+	fileContent, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+	fileContent, err = decompress(fileContent, "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress %s: %w", configFile, err)
+	}
+
+	configType := source.configType
+	if configType == "" {
+		configType = configTypeFromFileName(configFile)
+	}
+	return newViperConfigFromReader(bytes.NewReader(fileContent), configType)
+}
+
+// configFileName returns the config file passed to this source or
+// the first default config file found in search paths.
+func (source *FileConfigSource) configFileName() (string, error) {
+
 	if source.configFile != nil {
+		return *source.configFile, nil
+	}
 
-		fileContent, err := os.ReadFile(*source.configFile)
-		if err != nil {
-			return nil, err
+	for _, searchPath := range fileConfigSearchPaths {
+		for _, configType := range supportedConfigTypes {
+			configFile := filepath.Join(os.ExpandEnv(searchPath), "config."+configType)
+			if info, err := os.Stat(configFile); err == nil && !info.IsDir() {
+				return configFile, nil
+			}
 		}
-		return newViperConfigFromReader(bytes.NewReader(fileContent))
 	}
+	return "", configFileNotFoundError(fileConfigSearchPaths)
+}
 
-	viperConfig := viper.New()
-	viperConfig.AddConfigPath(".")
-	viperConfig.AddConfigPath("$HOME/")
-	viperConfig.AddConfigPath("$HOME/go_config/")
-	viperConfig.AddConfigPath("/etc/go_config/")
-	viperConfig.SetConfigName("config")
-	viperConfig.SetConfigType("yaml")
-	err := viperConfig.ReadInConfig()
+// configFileNotFoundError returns a viper.ConfigFileNotFoundError for a config file named config
+// in passed search paths, so callers can still check for it by errors.As. Search paths in the
+// error are expanded, e.g. $HOME/ becomes /home/user.
+func configFileNotFoundError(searchPaths []string) error {
 
-	var config Config
-	if err == nil {
-		config = &ViperConfig{config: viperConfig}
+	viperConfig := viper.NewWithOptions(viper.WithFinder(noConfigFileFinder{}))
+	for _, searchPath := range searchPaths {
+		viperConfig.AddConfigPath(searchPath)
 	}
-	return config, err
+	viperConfig.SetConfigName("config")
+	return viperConfig.ReadInConfig()
+}
+
+// noConfigFileFinder is a viper.Finder which never finds a config file.
+type noConfigFileFinder struct{}
+
+// Find returns no files, search for config files is done by FileConfigSource itself.
+func (noConfigFileFinder) Find(afero.Fs) ([]string, error) {
+	return nil, nil
 }
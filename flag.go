@@ -0,0 +1,61 @@
+package config
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// FlagConfigSource creates a config from command line flags.
+type FlagConfigSource struct {
+
+	// Flags defined with package flag.
+	flags *flag.FlagSet
+
+	// Flags defined with package github.com/spf13/pflag.
+	pflags *pflag.FlagSet
+}
+
+// NewFlagConfigSource returns a config source for passed flag set. Flags have to be parsed
+// before config is loaded. If flag set is nil, flag.CommandLine will be used.
+func NewFlagConfigSource(flags *flag.FlagSet) ConfigSource {
+
+	if flags == nil {
+		flags = flag.CommandLine
+	}
+	return &FlagConfigSource{flags: flags}
+}
+
+// NewPFlagConfigSource returns a config source for passed pflag set. Flags have to be parsed
+// before config is loaded. If flag set is nil, pflag.CommandLine will be used.
+func NewPFlagConfigSource(flags *pflag.FlagSet) ConfigSource {
+
+	if flags == nil {
+		flags = pflag.CommandLine
+	}
+	return &FlagConfigSource{pflags: flags}
+}
+
+// Load returns all explicitly set flags as ViperConfig. Flag names are split into nested
+// keys by dots, e.g. value of --server.port will be available as server.port.
+// Values of pflag slice flags are available as string slices.
+func (source *FlagConfigSource) Load() (Config, error) {
+
+	settings := make(map[string]any)
+	if source.flags != nil {
+		source.flags.Visit(func(f *flag.Flag) {
+			setNestedValue(settings, strings.Split(f.Name, "."), f.Value.String())
+		})
+	}
+	if source.pflags != nil {
+		source.pflags.Visit(func(f *pflag.Flag) {
+			var value any = f.Value.String()
+			if sliceValue, ok := f.Value.(pflag.SliceValue); ok {
+				value = sliceValue.GetSlice()
+			}
+			setNestedValue(settings, strings.Split(f.Name, "."), value)
+		})
+	}
+	return newViperConfigFromMap(settings)
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"mime"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/magiconair/properties"
+	"github.com/spf13/viper"
+)
+
+// supportedConfigTypes are all config formats, in order of preference, which can be used to create a config.
+var supportedConfigTypes = []string{"yml", "yaml", "json", "toml", "properties", "props", "prop", "env", "dotenv"}
+
+// contentTypeConfigTypes maps media types to config formats.
+var contentTypeConfigTypes = map[string]string{
+	"application/yaml":       "yaml",
+	"application/x-yaml":     "yaml",
+	"text/yaml":              "yaml",
+	"text/x-yaml":            "yaml",
+	"application/json":       "json",
+	"text/json":              "json",
+	"application/toml":       "toml",
+	"text/toml":              "toml",
+	"text/x-java-properties": "properties",
+	"text/x-properties":      "properties",
+	"application/x-dotenv":   "dotenv",
+}
+
+// configDecoders provides decoders for all supported config formats to viper.
+var configDecoders viper.DecoderRegistry = &decoderRegistry{DecoderRegistry: viper.NewCodecRegistry()}
+
+// decoderRegistry adds support for Java properties to the formats supported by viper itself
+// and replaces viper's dotenv decoder, so nested keys are supported.
+type decoderRegistry struct {
+	viper.DecoderRegistry
+}
+
+// Decoder returns a decoder for passed config format.
+func (registry *decoderRegistry) Decoder(format string) (viper.Decoder, error) {
+
+	switch strings.ToLower(format) {
+	case "properties", "props", "prop":
+		return propertiesDecoder{}, nil
+	case "env", "dotenv":
+		return dotEnvDecoder{}, nil
+	default:
+		return registry.DecoderRegistry.Decoder(format)
+	}
+}
+
+// configTypeFromFileName returns the config format for extension of passed file name.
+// Extensions of compressed files are ignored, e.g. config.yml.gz is detected as yml.
+// Returns an empty string if extension isn't a supported config format.
+func configTypeFromFileName(fileName string) string {
+
+	extension := strings.ToLower(strings.TrimPrefix(filepath.Ext(trimCompressionExtension(fileName)), "."))
+	if slices.Contains(supportedConfigTypes, extension) {
+		return extension
+	}
+	return ""
+}
+
+// configTypeFromContentType returns the config format for passed media type, e.g. from
+// a Content-Type header. Returns an empty string if it's not a supported config format.
+func configTypeFromContentType(contentType string) string {
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return contentTypeConfigTypes[mediaType]
+}
+
+// propertiesDecoder decodes Java properties. Keys are split into nested keys by dots.
+type propertiesDecoder struct{}
+
+// Decode parses passed properties and writes them to given config tree.
+func (decoder propertiesDecoder) Decode(content []byte, settings map[string]any) error {
+
+	props, err := properties.Load(content, properties.UTF8)
+	if err != nil {
+		return err
+	}
+	for _, key := range props.Keys() {
+		value, _ := props.Get(key)
+		setNestedValue(settings, strings.Split(key, "."), value)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+)
+
+// fsConfigSearchPaths are directories searched for a default config file in a file system.
+var fsConfigSearchPaths = []string{".", "config", "go_config"}
+
+// FSConfigSource reads a config file from a file system, e.g. an embed.FS.
+type FSConfigSource struct {
+
+	// File system to read config files from.
+	fsys fs.FS
+
+	// Optional path of a config file in the file system.
+	configFile *string
+}
+
+// NewFSConfigSource returns a new config source for given file in passed file system.
+// If you don't pass a specific config file this source will have a look
+// at different places for a default config file.
+// See Load method for more details.
+func NewFSConfigSource(fsys fs.FS, configFile *string) ConfigSource {
+	return &FSConfigSource{fsys: fsys, configFile: configFile}
+}
+
+// Load reads a config file from the file system and returns a ViperConfig.
+// It uses the config file you've set during creating this source or
+// it tries to find a file named config with a supported extension, e.g. config.yml,
+// in following directories.
+// - root directory, "."
+// - config directory, "config/"
+// - go_config directory, "go_config/"
+// Format of the config file is detected by its extension.
+func (source *FSConfigSource) Load() (Config, error) {
+
+	configFile, err := source.configFileName()
+	if err != nil {
+		return nil, err
+	}
+
+	fileContent, err := fs.ReadFile(source.fsys, configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := newViperConfigFromReader(bytes.NewReader(fileContent), configTypeFromFileName(configFile))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", configFile, err)
+	}
+	return config, nil
+}
+
+// configFileName returns the config file passed to this source or
+// the first default config file found in search paths.
+func (source *FSConfigSource) configFileName() (string, error) {
+
+	if source.configFile != nil {
+		return *source.configFile, nil
+	}
+
+	for _, searchPath := range fsConfigSearchPaths {
+		for _, configType := range supportedConfigTypes {
+			configFile := path.Join(searchPath, "config."+configType)
+			if info, err := fs.Stat(source.fsys, configFile); err == nil && !info.IsDir() {
+				return configFile, nil
+			} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return "", err
+			}
+		}
+	}
+	return "", fmt.Errorf("no config file found in %v", fsConfigSearchPaths)
+}
@@ -0,0 +1,215 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// durationType is the reflection type of time.Duration.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// GetAs returns config value for passed key converted to type T, or given default value
+// if there's no value for this key. Supported are all built-in scalar types, time.Duration,
+// slices and maps with string keys of these types. Durations use the same units as
+// GetAsDuration. Integers are checked for overflow. All other types, e.g. structs, are
+// decoded the same way as Unmarshal does. If conversion fails, passed default value is
+// returned together with a ConversionError.
+func GetAs[T any](cfg Config, key string, def T) (T, error) {
+
+	value, ok := rawValue(cfg, key)
+	if !ok {
+		return def, nil
+	}
+
+	var result T
+	target := reflect.ValueOf(&result).Elem()
+	if err := convertValue(value, target); err != nil {
+		return def, &ConversionError{Key: key, Value: value, Type: target.Type().String(), Err: err}
+	}
+	return result, nil
+}
+
+// rawValue returns the unconverted config value for passed key.
+func rawValue(cfg Config, key string) (any, bool) {
+
+	if !cfg.Has(key) {
+		return nil, false
+	}
+	if viperConfig, ok := cfg.(*ViperConfig); ok {
+		return viperConfig.config.Get(key), true
+	}
+
+	var value any = cfg.AllSettings()
+	for _, pathElement := range strings.Split(strings.ToLower(key), ".") {
+		settings, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = settings[pathElement]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// convertValue converts passed config value to the type of given target and assigns it.
+func convertValue(value any, target reflect.Value) error {
+
+	if value == nil {
+		return errors.New("missing value")
+	}
+
+	if target.Type() == durationType {
+		duration, err := convertDuration(value)
+		if err != nil {
+			return err
+		}
+		target.SetInt(int64(duration))
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.Bool:
+		b, err := toBool(value)
+		if err != nil {
+			return err
+		}
+		target.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, err := toInt(value, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetInt(number)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		number, err := toUint(value, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetUint(number)
+	case reflect.Float32, reflect.Float64:
+		number, err := toFloat(value, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetFloat(number)
+	case reflect.Complex64, reflect.Complex128:
+		number, err := toComplex(value, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetComplex(number)
+	case reflect.String:
+		switch reflect.ValueOf(value).Kind() {
+		case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+			return fmt.Errorf("unsupported type %T", value)
+		}
+		target.SetString(fmt.Sprint(value))
+	case reflect.Slice:
+		return convertSlice(value, target)
+	case reflect.Map:
+		if target.Type().Key().Kind() != reflect.String {
+			return decodeValue(value, target)
+		}
+		return convertMap(value, target)
+	case reflect.Interface:
+		if !reflect.TypeOf(value).AssignableTo(target.Type()) {
+			return fmt.Errorf("unsupported type %T", value)
+		}
+		target.Set(reflect.ValueOf(value))
+	default:
+		return decodeValue(value, target)
+	}
+	return nil
+}
+
+// convertDuration converts passed config value to a duration. Strings, integers and
+// whole number floats, e.g. from JSON, are converted using the same units as GetAsDuration.
+func convertDuration(value any) (time.Duration, error) {
+
+	if duration, ok := value.(time.Duration); ok {
+		return duration, nil
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Float32, reflect.Float64:
+		number, err := toInt(value, 64)
+		if err != nil {
+			return 0, err
+		}
+		if duration := toDuration(strconv.FormatInt(number, 10)); duration != nil {
+			return *duration, nil
+		}
+	case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if duration := toDuration(strings.TrimSpace(fmt.Sprint(value))); duration != nil {
+			return *duration, nil
+		}
+	}
+	return 0, errors.New("invalid duration")
+}
+
+// convertSlice converts each element of passed slice config value to the element type of given target.
+func convertSlice(value any, target reflect.Value) error {
+
+	values := reflect.ValueOf(value)
+	if values.Kind() != reflect.Slice && values.Kind() != reflect.Array {
+		return fmt.Errorf("unsupported type %T", value)
+	}
+
+	slice := reflect.MakeSlice(target.Type(), values.Len(), values.Len())
+	for idx := 0; idx < values.Len(); idx++ {
+		if err := convertValue(values.Index(idx).Interface(), slice.Index(idx)); err != nil {
+			return fmt.Errorf("element %d: %w", idx, err)
+		}
+	}
+	target.Set(slice)
+	return nil
+}
+
+// convertMap converts each value of passed map config value to the value type of given target.
+func convertMap(value any, target reflect.Value) error {
+
+	values := reflect.ValueOf(value)
+	if values.Kind() != reflect.Map {
+		return fmt.Errorf("unsupported type %T", value)
+	}
+
+	result := reflect.MakeMapWithSize(target.Type(), values.Len())
+	iter := values.MapRange()
+	for iter.Next() {
+		key := fmt.Sprint(iter.Key().Interface())
+		element := reflect.New(target.Type().Elem()).Elem()
+		if err := convertValue(iter.Value().Interface(), element); err != nil {
+			return fmt.Errorf("key %s: %w", key, err)
+		}
+		result.SetMapIndex(reflect.ValueOf(key).Convert(target.Type().Key()), element)
+	}
+	target.Set(result)
+	return nil
+}
+
+// decodeValue decodes passed config value into given target the same way as Unmarshal does,
+// except that durations use the same units as GetAsDuration.
+func decodeValue(value any, target reflect.Value) error {
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           target.Addr().Interface(),
+		WeaklyTypedInput: true,
+		DecodeHook: mapstructure.DecodeHookFuncType(func(from reflect.Type, to reflect.Type, data any) (any, error) {
+			if to != durationType || from == durationType {
+				return data, nil
+			}
+			return convertDuration(data)
+		}),
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(value)
+}
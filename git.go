@@ -0,0 +1,155 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// GitConfigSource reads a config file from a git repository.
+type GitConfigSource struct {
+
+	// Path of a local repository or URL of a remote repository.
+	repository string
+
+	// Branch, tag or commit to read the config file from.
+	ref string
+
+	// Path of the config file in the repository.
+	configFile string
+
+	// Protects resolved commit.
+	mutex sync.Mutex
+
+	// Commit the config file has been loaded from most recently.
+	commit *string
+}
+
+// NewGitConfigSource returns a new config source for a config file in given git repository.
+// Repository can be a path to a local repository or an URL of a remote repository, which will be
+// cloned into memory on each load. Ref can be a branch, a tag or a commit, HEAD is used if ref is empty.
+// For a branch or a tag only its latest commit is cloned, a commit requires a full clone.
+func NewGitConfigSource(repository, ref, configFile string) ConfigSource {
+	return &GitConfigSource{repository: repository, ref: ref, configFile: configFile}
+}
+
+// Load reads the config file at the ref of this source and returns a ViperConfig.
+// Format of the config file is detected by its extension.
+func (source *GitConfigSource) Load() (Config, error) {
+
+	repository, revision, err := source.openRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := repository.ResolveRevision(revision)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve %s in %s: %w", revision, source.repository, err)
+	}
+
+	commit, err := repository.CommitObject(*hash)
+	if err != nil {
+		return nil, err
+	}
+	file, err := commit.File(source.configFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s at %s: %w", source.configFile, hash, err)
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := newViperConfigFromReader(strings.NewReader(content), configTypeFromFileName(source.configFile))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s at %s: %w", source.configFile, hash, err)
+	}
+
+	commitHash := hash.String()
+	source.mutex.Lock()
+	source.commit = &commitHash
+	source.mutex.Unlock()
+	return config, nil
+}
+
+// Commit returns the SHA of the commit the config file has been loaded from most recently.
+// Returns nil if no config has been loaded, yet.
+func (source *GitConfigSource) Commit() *string {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	return source.commit
+}
+
+// openRepository opens a local repository or clones a remote repository into memory and returns
+// it together with the revision to read the config file from. If ref is a branch or a tag of a
+// remote repository, only its latest commit will be cloned. Other refs, e.g. a commit SHA, require
+// a full clone of the remote repository.
+func (source *GitConfigSource) openRepository() (*git.Repository, plumbing.Revision, error) {
+
+	revision := plumbing.Revision(source.ref)
+	if source.ref == "" {
+		revision = plumbing.Revision(plumbing.HEAD)
+	}
+
+	if info, err := os.Stat(source.repository); err == nil && info.IsDir() {
+		repository, err := git.PlainOpen(source.repository)
+		return repository, revision, err
+	}
+
+	referenceName, err := source.remoteReference()
+	if err != nil {
+		return nil, "", err
+	}
+	if referenceName == nil {
+		repository, err := git.Clone(memory.NewStorage(), nil, &git.CloneOptions{URL: source.repository})
+		return repository, revision, err
+	}
+
+	repository, err := git.Clone(memory.NewStorage(), nil, &git.CloneOptions{
+		URL:           source.repository,
+		ReferenceName: *referenceName,
+		SingleBranch:  true,
+		Depth:         1,
+		Tags:          git.NoTags,
+	})
+	return repository, plumbing.Revision(plumbing.HEAD), err
+}
+
+// remoteReference returns the full name of the remote branch or tag matching the ref of this source,
+// or HEAD if ref is empty. Returns nil if ref is neither a branch nor a tag, e.g. a commit SHA.
+func (source *GitConfigSource) remoteReference() (*plumbing.ReferenceName, error) {
+
+	if source.ref == "" {
+		referenceName := plumbing.HEAD
+		return &referenceName, nil
+	}
+
+	remote := git.NewRemote(memory.NewStorage(), &gitconfig.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{source.repository},
+	})
+	references, err := remote.List(&git.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list references of %s: %w", source.repository, err)
+	}
+
+	candidates := []plumbing.ReferenceName{
+		plumbing.ReferenceName(source.ref),
+		plumbing.NewBranchReferenceName(source.ref),
+		plumbing.NewTagReferenceName(source.ref),
+	}
+	for _, candidate := range candidates {
+		for _, reference := range references {
+			if reference.Name() == candidate && (candidate.IsBranch() || candidate.IsTag()) {
+				return &candidate, nil
+			}
+		}
+	}
+	return nil, nil
+}
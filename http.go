@@ -0,0 +1,119 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultHTTPTimeout is the max time a request to fetch a config document can take.
+const defaultHTTPTimeout = 30 * time.Second
+
+// HTTPConfigSource loads a config from a HTTP(S) endpoint.
+type HTTPConfigSource struct {
+
+	// URL of the config document.
+	url string
+
+	// Additional headers which will be send with each request.
+	headers map[string]string
+
+	// Optional token for bearer authentication.
+	bearerToken *string
+
+	// Client used to send requests.
+	client *http.Client
+
+	// Protects cached config document.
+	mutex sync.Mutex
+
+	// ETag of the cached config document.
+	etag *string
+
+	// Cached content of the last fetched config document.
+	content []byte
+
+	// Format of the cached config document.
+	configType string
+}
+
+// NewHTTPConfigSource returns a config source which fetches a config document from given URL.
+// Passed headers are added to each request. If a bearer token is given it will be used
+// for authentication. Config documents are cached and an unchanged document will not
+// be downloaded again if the server supports ETags.
+func NewHTTPConfigSource(url string, headers map[string]string, bearerToken *string) ConfigSource {
+	return &HTTPConfigSource{
+		url:         url,
+		headers:     headers,
+		bearerToken: bearerToken,
+		client:      &http.Client{Timeout: defaultHTTPTimeout},
+	}
+}
+
+// Load fetches config document from remote server and pass it to a ViperConfig.
+func (source *HTTPConfigSource) Load() (Config, error) {
+
+	reader, configType, err := source.readConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return newViperConfigFromReader(reader, configType)
+}
+
+// readConfig fetches the config document and returns it as an io.Reader together with its format.
+// A cached document is used if the server responds that it has not been modified since last request.
+// Format is detected by Content-Type header or by extension of the URL path.
+func (source *HTTPConfigSource) readConfig() (io.Reader, string, error) {
+
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	request, err := http.NewRequest(http.MethodGet, source.url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	for name, value := range source.headers {
+		request.Header.Set(name, value)
+	}
+	if source.bearerToken != nil {
+		request.Header.Set("Authorization", "Bearer "+*source.bearerToken)
+	}
+	if source.etag != nil {
+		request.Header.Set("If-None-Match", *source.etag)
+	}
+
+	response, err := source.client.Do(request)
+	if err != nil {
+		return nil, "", err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusNotModified:
+		if source.content == nil {
+			return nil, "", fmt.Errorf("unexpected response status for %s: %s", source.url, response.Status)
+		}
+	case http.StatusOK:
+		content, err := io.ReadAll(response.Body)
+		if err != nil {
+			return nil, "", err
+		}
+		source.content = content
+		source.configType = configTypeFromContentType(response.Header.Get("Content-Type"))
+		if source.configType == "" {
+			source.configType = configTypeFromFileName(request.URL.Path)
+		}
+		source.etag = nil
+		if etag := response.Header.Get("ETag"); etag != "" {
+			source.etag = &etag
+		}
+	default:
+		return nil, "", fmt.Errorf("unable to fetch config from %s: %s", source.url, response.Status)
+	}
+
+	return bytes.NewReader(source.content), source.configType, nil
+}
@@ -30,6 +30,30 @@ type Config interface {
 	// or return passed default value it there's no value for tis key.
 	GetAsIntSlice(key string, defaultValue *[]int) *[]int
 
+	// GetAsInt64 returns config value for passed key as int64 or given default value
+	// if there's no value for this key, the value is not an integer or overflows int64.
+	GetAsInt64(key string, defaultValue *int64) *int64
+
+	// GetAsInt64Slice returns config values for passed key as int64 slice or given default
+	// value if there's no value for this key or one of the values can't be converted.
+	GetAsInt64Slice(key string, defaultValue *[]int64) *[]int64
+
+	// GetAsUint64 returns config value for passed key as uint64 or given default value
+	// if there's no value for this key, the value is not an integer, negative or overflows uint64.
+	GetAsUint64(key string, defaultValue *uint64) *uint64
+
+	// GetAsUint64Slice returns config values for passed key as uint64 slice or given default
+	// value if there's no value for this key or one of the values can't be converted.
+	GetAsUint64Slice(key string, defaultValue *[]uint64) *[]uint64
+
+	// GetAsFloat returns config value for passed key as float64 or given default value
+	// if there's no value for this key or the value is not a number.
+	GetAsFloat(key string, defaultValue *float64) *float64
+
+	// GetAsFloatSlice returns config values for passed key as float64 slice or given default
+	// value if there's no value for this key or one of the values can't be converted.
+	GetAsFloatSlice(key string, defaultValue *[]float64) *[]float64
+
 	// GetAsBool returns config value as bool or given default value
 	// if there's no value for this key or conversion to bool fails.
 	GetAsBool(key string, defaultValue *bool) *bool
@@ -40,6 +64,21 @@ type Config interface {
 	// If there's no unit default will be seconds.
 	GetAsDuration(key string, defaultValue *time.Duration) *time.Duration
 
+	// GetIntE returns config value for passed key as int. It returns an error which wraps
+	// ErrKeyNotFound if there's no value for this key or a ConversionError if the value
+	// is not an integer or overflows int.
+	GetIntE(key string) (int, error)
+
+	// GetBoolE returns config value for passed key as bool. It returns an error which wraps
+	// ErrKeyNotFound if there's no value for this key or a ConversionError if the value
+	// is not a valid bool.
+	GetBoolE(key string) (bool, error)
+
+	// GetDurationE returns config value for passed key as duration, using the same units
+	// as GetAsDuration. It returns an error which wraps ErrKeyNotFound if there's no value
+	// for this key or a ConversionError if the value is not a valid duration.
+	GetDurationE(key string) (time.Duration, error)
+
 	// GetSliceOfMap returns all config values as a slice of maps.
 	GetAsSliceOfMaps(key string) []map[string]string
 
@@ -47,4 +86,18 @@ type Config interface {
 	// The `rawVal` parameter should be a pointer to a struct or map where the
 	// configuration values will be unmarshaled. Returns an error if unmarshaling fails.
 	Unmarshal(rawVal any) error
+
+	// Sub returns config for the subtree at passed key. Keys of returned config are
+	// relative to this subtree, e.g. database.host becomes host for Sub("database").
+	// Returns an empty config if there's no subtree for passed key.
+	Sub(key string) Config
+
+	// Keys returns all keys of this config in dot notation, sorted alphabetically.
+	Keys() []string
+
+	// Has returns true if there's a config value for passed key.
+	Has(key string) bool
+
+	// AllSettings returns the whole config as nested map.
+	AllSettings() map[string]any
 }
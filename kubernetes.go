@@ -0,0 +1,73 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// KubernetesVolumeConfigSource reads config from a mounted Kubernetes ConfigMap or Secret volume,
+// where each file represents a single config value.
+type KubernetesVolumeConfigSource struct {
+
+	// Mount path of the volume.
+	directory string
+}
+
+// NewKubernetesVolumeConfigSource returns a new config source for a ConfigMap or Secret
+// volume mounted at given directory.
+func NewKubernetesVolumeConfigSource(directory string) ConfigSource {
+	return &KubernetesVolumeConfigSource{directory: directory}
+}
+
+// Load reads all files in the volume and returns them as ViperConfig. Each file name is
+// used as key and file content, without trailing line breaks, as value. Subdirectories are
+// mapped to nested keys. Symlinks are followed, so the ..data indirection used by Kubernetes
+// is resolved, but internal entries starting with ".." are skipped.
+func (source *KubernetesVolumeConfigSource) Load() (Config, error) {
+
+	settings, err := source.readDirectory(source.directory)
+	if err != nil {
+		return nil, err
+	}
+	return newViperConfigFromMap(settings)
+}
+
+// readDirectory reads all files in passed directory and its subdirectories into a config tree.
+func (source *KubernetesVolumeConfigSource) readDirectory(directory string) (map[string]any, error) {
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]any)
+	for _, entry := range entries {
+
+		if strings.HasPrefix(entry.Name(), "..") {
+			continue
+		}
+
+		fileName := filepath.Join(directory, entry.Name())
+		info, err := os.Stat(fileName)
+		if err != nil {
+			return nil, err
+		}
+
+		if info.IsDir() {
+			subSettings, err := source.readDirectory(fileName)
+			if err != nil {
+				return nil, err
+			}
+			settings[entry.Name()] = subSettings
+			continue
+		}
+
+		fileContent, err := os.ReadFile(fileName)
+		if err != nil {
+			return nil, err
+		}
+		settings[entry.Name()] = strings.TrimRight(string(fileContent), "\r\n")
+	}
+	return settings, nil
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+)
+
+// LayeredConfigSource combines config from multiple sources into a single config.
+type LayeredConfigSource struct {
+
+	// Config sources ordered by precedence, lowest first.
+	sources []ConfigSource
+}
+
+// NewLayeredConfigSource returns a config source which loads config from all passed sources
+// and merges them into one config. Sources are ordered by precedence, values of a later
+// source will overwrite values of all previous sources for the same key.
+func NewLayeredConfigSource(sources ...ConfigSource) ConfigSource {
+	return &LayeredConfigSource{sources: sources}
+}
+
+// Load reads config from all sources and deep merges them into a single ViperConfig.
+// Returns with an error if one of the sources fails to load its config.
+func (source *LayeredConfigSource) Load() (Config, error) {
+
+	settings := make(map[string]any)
+	for idx, configSource := range source.sources {
+
+		config, err := configSource.Load()
+		if err != nil {
+			return nil, fmt.Errorf("unable to load config from source %d: %w", idx, err)
+		}
+
+		mergeSettings(settings, config.AllSettings())
+	}
+	return newViperConfigFromMap(settings)
+}
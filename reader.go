@@ -0,0 +1,63 @@
+package config
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// ReaderConfigSource reads a config from an io.Reader, e.g. stdin or a HTTP response body.
+type ReaderConfigSource struct {
+
+	// Reader to consume config content from.
+	reader io.Reader
+
+	// Format of config content, e.g. yaml or json.
+	configType string
+
+	// Protects buffered config content.
+	mutex sync.Mutex
+
+	// Content read from reader, available after first load.
+	content []byte
+}
+
+// NewReaderConfigSource returns a config source which reads config in passed format from given reader.
+// Supported formats are yaml, json, toml, properties and dotenv. If format is empty it defaults to YAML,
+// or to the format set by SetViperConfigType. Content compressed with gzip or zstd will be decompressed.
+func NewReaderConfigSource(reader io.Reader, format string) ConfigSource {
+	return &ReaderConfigSource{reader: reader, configType: format}
+}
+
+// Load reads all content from the reader and pass it to a ViperConfig. Content is read from
+// the reader on first load only and buffered, so subsequent loads return the same config.
+func (source *ReaderConfigSource) Load() (Config, error) {
+
+	content, err := source.readContent()
+	if err != nil {
+		return nil, err
+	}
+	return newViperConfigFromReader(bytes.NewReader(content), source.configType)
+}
+
+// readContent returns buffered config content. Content will be read from the reader if not already done.
+func (source *ReaderConfigSource) readContent() ([]byte, error) {
+
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	if source.content != nil {
+		return source.content, nil
+	}
+
+	content, err := io.ReadAll(source.reader)
+	if err != nil {
+		return nil, err
+	}
+	content, err = decompress(content, "")
+	if err != nil {
+		return nil, err
+	}
+	source.content = content
+	return source.content, nil
+}
@@ -6,14 +6,14 @@ import (
 	"errors"
 	"io"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
-// S3ConfigSource loads a YAML config from a file in an AWS S3 bucket.
+// S3ConfigSource loads a config from a file in an AWS S3 bucket.
 type S3ConfigSource struct {
 
 	// AWS config for S3 access.
@@ -24,22 +24,57 @@ type S3ConfigSource struct {
 
 	// Path and file name for a config file.
 	key string
+
+	// Optional format of the config file, e.g. yaml or json.
+	configType string
+
+	// Optional version of the config file, latest version is used if not set.
+	versionId *string
+
+	// Protects version and ETag of loaded config file.
+	mutex sync.Mutex
+
+	// Version of last loaded config file.
+	loadedVersionId *string
+
+	// ETag of last loaded config file.
+	loadedETag *string
+}
+
+// S3ObjectVersion is a single version of a config file in an AWS S3 bucket.
+type S3ObjectVersion struct {
+
+	// Id of this version.
+	VersionId string
+
+	// ETag of the config file in this version.
+	ETag string
+
+	// Time this version has been created.
+	LastModified time.Time
+
+	// Size of the config file in bytes.
+	Size int64
+
+	// Indicates if this is the current version of the config file.
+	IsLatest bool
 }
 
 // NewS3ConfigSource returns a new S3 config source which uses the config file from the given S3 bucket.
 // If region is empty it will try to get current AWS region from environment variable AWS_REGION.
 func NewS3ConfigSource(bucket, key string, region *string) (ConfigSource, error) {
-	var cfg aws.Config
-	var err error
 
-	if region != nil {
-		cfg, err = config.LoadDefaultConfig(context.TODO(), config.WithRegion(*region))
-	} else if envRegion, ok := os.LookupEnv("AWS_REGION"); ok {
-		cfg, err = config.LoadDefaultConfig(context.TODO(), config.WithRegion(envRegion))
-	} else {
-		cfg, err = config.LoadDefaultConfig(context.TODO())
+	source, err := newS3ConfigSource(bucket, key, region)
+	if err != nil {
+		return nil, err
 	}
+	return source, nil
+}
 
+// newS3ConfigSource returns a new S3 config source for given bucket and key.
+func newS3ConfigSource(bucket, key string, region *string) (*S3ConfigSource, error) {
+
+	cfg, err := newAwsConfig(region)
 	if err != nil {
 		return nil, err
 	}
@@ -51,6 +86,34 @@ func NewS3ConfigSource(bucket, key string, region *string) (ConfigSource, error)
 	}, nil
 }
 
+// NewS3ConfigSourceWithFormat returns a new S3 config source for a config file in passed format,
+// e.g. yaml, json, toml, properties or dotenv. Format will be used regardless of Content-Type
+// and extension of the S3 object.
+// If region is empty it will try to get current AWS region from environment variable AWS_REGION.
+func NewS3ConfigSourceWithFormat(bucket, key, format string, region *string) (ConfigSource, error) {
+	return NewS3ConfigSourceWithVersion(bucket, key, "", region, &format)
+}
+
+// NewS3ConfigSourceWithVersion returns a new S3 config source which uses passed version of
+// the config file from the given S3 bucket, instead of latest version. Latest version is used
+// if versionId is empty. Format is detected by Content-Type or extension of the S3 object
+// if no format has been passed.
+// If region is empty it will try to get current AWS region from environment variable AWS_REGION.
+func NewS3ConfigSourceWithVersion(bucket, key, versionId string, region, format *string) (ConfigSource, error) {
+
+	source, err := newS3ConfigSource(bucket, key, region)
+	if err != nil {
+		return nil, err
+	}
+	if versionId != "" {
+		source.versionId = aws.String(versionId)
+	}
+	if format != nil {
+		source.configType = *format
+	}
+	return source, nil
+}
+
 // NewS3ConfigSourceFromEnv creates a new S3 config source using environment variables:
 // AWS_REGION, GO_CONFIG_S3_BUCKET, GO_CONFIG_S3_KEY
 func NewS3ConfigSourceFromEnv() (ConfigSource, error) {
@@ -74,30 +137,132 @@ func NewS3ConfigSourceFromEnv() (ConfigSource, error) {
 }
 
 // Load config file from S3 and pass it to a ViperConfig.
+// Format of the config file is detected by Content-Type or by extension of the S3 object,
+// if no explicit format has been set.
 func (source *S3ConfigSource) Load() (Config, error) {
 
-	reader, err := source.readConfig()
+	reader, configType, err := source.readConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	return newViperConfigFromReader(reader)
+	return newViperConfigFromReader(reader, configType)
 }
 
-// readConfig downloads the config file from AWS S3 bucket and returns it as an io.Reader.
-func (source *S3ConfigSource) readConfig() (io.Reader, error) {
+// readConfig downloads the config file from AWS S3 bucket and returns it as an io.Reader
+// together with its format. Config files compressed with gzip or zstd will be decompressed.
+func (source *S3ConfigSource) readConfig() (io.Reader, string, error) {
 
 	client := s3.NewFromConfig(source.cfg)
-	downloader := manager.NewDownloader(client)
+	object, err := readS3Object(client, source.bucket, source.key, source.versionId)
+	if err != nil {
+		return nil, "", err
+	}
 
-	buf := manager.NewWriteAtBuffer([]byte{})
-	_, err := downloader.Download(context.TODO(), buf, &s3.GetObjectInput{
+	source.mutex.Lock()
+	source.loadedVersionId = object.versionId
+	source.loadedETag = object.etag
+	source.mutex.Unlock()
+
+	configType := source.configType
+	if configType == "" {
+		configType = configTypeFromContentType(object.contentType)
+	}
+	if configType == "" {
+		configType = configTypeFromFileName(source.key)
+	}
+	return bytes.NewReader(object.content), configType, nil
+}
+
+// VersionId returns the version of the config file loaded most recently. Returns nil if
+// no config has been loaded, yet, or if versioning isn't enabled for the S3 bucket.
+func (source *S3ConfigSource) VersionId() *string {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	return source.loadedVersionId
+}
+
+// ETag returns the ETag of the config file loaded most recently. Returns nil if
+// no config has been loaded, yet.
+func (source *S3ConfigSource) ETag() *string {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	return source.loadedETag
+}
+
+// ListVersions returns all versions of the config file, ordered from latest to oldest.
+// Versioning has to be enabled for the S3 bucket to get previous versions.
+func (source *S3ConfigSource) ListVersions() ([]S3ObjectVersion, error) {
+
+	client := s3.NewFromConfig(source.cfg)
+	var versions []S3ObjectVersion
+	paginator := s3.NewListObjectVersionsPaginator(client, &s3.ListObjectVersionsInput{
 		Bucket: aws.String(source.bucket),
-		Key:    aws.String(source.key),
+		Prefix: aws.String(source.key),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		for _, version := range output.Versions {
+			if aws.ToString(version.Key) != source.key {
+				continue
+			}
+			versions = append(versions, S3ObjectVersion{
+				VersionId:    aws.ToString(version.VersionId),
+				ETag:         aws.ToString(version.ETag),
+				LastModified: aws.ToTime(version.LastModified),
+				Size:         aws.ToInt64(version.Size),
+				IsLatest:     aws.ToBool(version.IsLatest),
+			})
+		}
+	}
+	return versions, nil
+}
+
+// s3Object is a downloaded object from an AWS S3 bucket.
+type s3Object struct {
+
+	// Decompressed content of the object.
+	content []byte
+
+	// Content-Type of the object.
+	contentType string
+
+	// Version of the object.
+	versionId *string
+
+	// ETag of the object.
+	etag *string
+}
+
+// readS3Object downloads an object from AWS S3 bucket and returns it with decompressed content.
+// If a version is passed this version of the object will be downloaded instead of latest version.
+func readS3Object(client *s3.Client, bucket, key string, versionId *string) (*s3Object, error) {
+
+	output, err := client.GetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(key),
+		VersionId: versionId,
 	})
 	if err != nil {
 		return nil, err
 	}
+	defer output.Body.Close()
 
-	return bytes.NewReader(buf.Bytes()), nil
+	content, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, err
+	}
+	content, err = decompress(content, aws.ToString(output.ContentEncoding))
+	if err != nil {
+		return nil, err
+	}
+	return &s3Object{
+		content:     content,
+		contentType: aws.ToString(output.ContentType),
+		versionId:   output.VersionId,
+		etag:        output.ETag,
+	}, nil
 }
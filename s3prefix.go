@@ -0,0 +1,94 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3PrefixConfigSource loads all YAML config files below a prefix in an AWS S3 bucket.
+type S3PrefixConfigSource struct {
+
+	// AWS config for S3 access.
+	cfg aws.Config
+
+	// Bucket the config files are located in.
+	bucket string
+
+	// Prefix of config files, e.g. configs/myservice/
+	prefix string
+}
+
+// NewS3PrefixConfigSource returns a new S3 config source which uses all config files below given prefix
+// in passed S3 bucket. If region is empty it will try to get current AWS region from environment variable AWS_REGION.
+func NewS3PrefixConfigSource(bucket, prefix string, region *string) (ConfigSource, error) {
+
+	cfg, err := newAwsConfig(region)
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3PrefixConfigSource{
+		cfg:    cfg,
+		bucket: bucket,
+		prefix: prefix,
+	}, nil
+}
+
+// Load lists all objects with extension .yml or .yaml, optional compressed with gzip or zstd,
+// below the prefix and deep merges them in key order into a single ViperConfig. Values of an
+// object will overwrite values of all previous objects.
+// Returns with an error if there's no config file below the prefix.
+func (source *S3PrefixConfigSource) Load() (Config, error) {
+
+	client := s3.NewFromConfig(source.cfg)
+	keys, err := source.listConfigFiles(client)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no config files found in s3://%s/%s", source.bucket, source.prefix)
+	}
+
+	settings := make(map[string]any)
+	for _, key := range keys {
+
+		object, err := readS3Object(client, source.bucket, key, nil)
+		if err != nil {
+			return nil, err
+		}
+		config, err := newViperConfigFromReader(bytes.NewReader(object.content), "yaml")
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse s3://%s/%s: %w", source.bucket, key, err)
+		}
+		mergeSettings(settings, config.AllSettings())
+	}
+	return newViperConfigFromMap(settings)
+}
+
+// listConfigFiles returns keys of all YAML files below the prefix in lexical order.
+func (source *S3PrefixConfigSource) listConfigFiles(client *s3.Client) ([]string, error) {
+
+	var keys []string
+	paginator := s3.NewListObjectsV2Paginator(client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(source.bucket),
+		Prefix: aws.String(source.prefix),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range output.Contents {
+			if key := aws.ToString(object.Key); isYamlFile(trimCompressionExtension(key)) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
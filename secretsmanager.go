@@ -0,0 +1,84 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+// secretsConfigKey is the namespace all secrets are available at.
+const secretsConfigKey = "secrets"
+
+// SecretsManagerConfigSource loads config from secrets in AWS Secrets Manager.
+type SecretsManagerConfigSource struct {
+
+	// AWS config for Secrets Manager access.
+	cfg aws.Config
+
+	// Names or ARNs of secrets to load.
+	secretIds []string
+
+	// Optional endpoint to use instead of default Secrets Manager endpoint.
+	endpoint *string
+}
+
+// NewSecretsManagerConfigSource returns a new config source which loads all given secrets.
+// If region is empty it will try to get current AWS region from environment variable AWS_REGION.
+// An endpoint can be passed to use a different endpoint than the default Secrets Manager endpoint.
+func NewSecretsManagerConfigSource(secretIds []string, region, endpoint *string) (ConfigSource, error) {
+
+	cfg, err := newAwsConfig(region)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SecretsManagerConfigSource{
+		cfg:       cfg,
+		secretIds: secretIds,
+		endpoint:  endpoint,
+	}, nil
+}
+
+// Load reads all secrets and pass them to a ViperConfig. Each secret is available at
+// secrets.<name>. JSON objects will be mapped to nested keys, e.g. value for key password
+// of a secret named database is available at secrets.database.password. All other
+// secrets are available as plain value.
+func (source *SecretsManagerConfigSource) Load() (Config, error) {
+
+	client := secretsmanager.NewFromConfig(source.cfg, func(options *secretsmanager.Options) {
+		if source.endpoint != nil {
+			options.BaseEndpoint = source.endpoint
+		}
+	})
+
+	secrets := make(map[string]any)
+	for _, secretId := range source.secretIds {
+
+		output, err := client.GetSecretValue(context.TODO(), &secretsmanager.GetSecretValueInput{
+			SecretId: aws.String(secretId),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		payload := output.SecretBinary
+		if output.SecretString != nil {
+			payload = []byte(*output.SecretString)
+		}
+		secrets[aws.ToString(output.Name)] = secretValue(payload)
+	}
+	return newViperConfigFromMap(map[string]any{secretsConfigKey: secrets})
+}
+
+// secretValue returns passed secret payload as nested map if it's a JSON object,
+// otherwise payload will be returned as string.
+func secretValue(payload []byte) any {
+
+	var values map[string]any
+	if err := json.Unmarshal(payload, &values); err == nil {
+		return values
+	}
+	return string(payload)
+}
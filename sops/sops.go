@@ -0,0 +1,62 @@
+// Package sops adds support for config encrypted with SOPS to github.com/tommzn/go-config.
+// Importing this package registers a decrypter, so YAML and JSON config encrypted with SOPS
+// is decrypted transparently by all config sources.
+//
+//	import _ "github.com/tommzn/go-config/sops"
+package sops
+
+import (
+	"bytes"
+
+	"github.com/getsops/sops/v3/decrypt"
+	config "github.com/tommzn/go-config"
+	"go.yaml.in/yaml/v3"
+)
+
+// sopsMetadataKey is the key of SOPS metadata in an encrypted config.
+const sopsMetadataKey = "sops"
+
+// sopsFormats maps config types to the format names used by SOPS.
+var sopsFormats = map[string]string{
+	"yaml": "yaml",
+	"yml":  "yaml",
+	"json": "json",
+}
+
+func init() {
+	config.RegisterDecrypter(Decrypter{})
+}
+
+// Decrypter decrypts config encrypted with SOPS. Decryption and MAC verification is done by SOPS
+// itself, so keys are looked up the same way as SOPS does, e.g. age identities from SOPS_AGE_KEY,
+// SOPS_AGE_KEY_FILE or sops/age/keys.txt in user config dir.
+type Decrypter struct{}
+
+// Decrypt returns decrypted content of passed SOPS encrypted config. Content is returned unchanged
+// if config type isn't supported by SOPS or content isn't encrypted.
+func (decrypter Decrypter) Decrypt(content []byte, configType string) ([]byte, error) {
+
+	format, ok := sopsFormats[configType]
+	if !ok || !isSopsEncrypted(content) {
+		return content, nil
+	}
+	return decrypt.Data(content, format)
+}
+
+// isSopsEncrypted returns true if passed content is a YAML or JSON document which contains SOPS metadata.
+func isSopsEncrypted(content []byte) bool {
+
+	if !bytes.Contains(content, []byte(sopsMetadataKey)) {
+		return false
+	}
+
+	var document struct {
+		Sops *struct {
+			Mac string `yaml:"mac"`
+		} `yaml:"sops"`
+	}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return false
+	}
+	return document.Sops != nil && document.Sops.Mac != ""
+}
@@ -0,0 +1,111 @@
+package sops
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	config "github.com/tommzn/go-config"
+)
+
+type SopsTestSuite struct {
+	suite.Suite
+}
+
+func TestSopsTestSuite(t *testing.T) {
+	suite.Run(t, new(SopsTestSuite))
+}
+
+func (suite *SopsTestSuite) SetupTest() {
+	suite.T().Setenv("XDG_CONFIG_HOME", suite.T().TempDir())
+	suite.T().Setenv("SOPS_AGE_KEY_FILE", "./testconfig.agekey")
+}
+
+func (suite *SopsTestSuite) TestSopsEncryptedConfig() {
+
+	// Fixtures have been encrypted by the sops CLI, version 3.13.3, with the age recipient of testconfig.agekey:
+	// sops encrypt --age <recipient> --unencrypted-suffix _unencrypted testconfig.sops.plain.yml > testconfig.sops.yml
+	// and the same for a JSON copy of testconfig.sops.plain.yml with --input-type json --output-type json.
+	configFile := "./testconfig.sops.yml"
+	cfg, err := config.NewFileConfigSource(&configFile).Load()
+	suite.Nil(err)
+	suite.assertDecryptedConfig(cfg)
+
+	plainConfigFile := "./testconfig.sops.plain.yml"
+	plainConfig, err := config.NewFileConfigSource(&plainConfigFile).Load()
+	suite.Nil(err)
+	suite.Equal(plainConfig.AllSettings(), cfg.AllSettings())
+
+	jsonConfigFile := "./testconfig.sops.json"
+	jsonConfig, err := config.NewFileConfigSource(&jsonConfigFile).Load()
+	suite.Nil(err)
+	suite.assertDecryptedConfig(jsonConfig)
+	suite.Equal(0.75, *jsonConfig.GetAsFloat("ratio", nil))
+
+	encryptedConfig, err := os.ReadFile(configFile)
+	suite.Nil(err)
+	cfg2, err2 := config.NewReaderConfigSource(strings.NewReader(string(encryptedConfig)), "yaml").Load()
+	suite.Nil(err2)
+	suite.assertDecryptedConfig(cfg2)
+
+	// Swapping encrypted values between keys has to fail.
+	lines := strings.Split(string(encryptedConfig), "\n")
+	key2Value := strings.TrimPrefix(lines[2], "key2: ")
+	lines[2] = "key2: " + strings.TrimPrefix(lines[1], "    key1: ")
+	lines[1] = "    key1: " + key2Value
+	cfg3, err3 := config.NewStaticConfigSource(strings.Join(lines, "\n")).Load()
+	suite.NotNil(err3)
+	suite.Nil(cfg3)
+
+	// Modified unencrypted values have to fail MAC verification.
+	tamperedConfig := strings.Replace(string(encryptedConfig), "public_unencrypted: visible", "public_unencrypted: modified", 1)
+	cfg4, err4 := config.NewStaticConfigSource(tamperedConfig).Load()
+	suite.NotNil(err4)
+	suite.Nil(cfg4)
+}
+
+func (suite *SopsTestSuite) TestSopsKeys() {
+
+	configFile := "./testconfig.sops.yml"
+	suite.T().Setenv("SOPS_AGE_KEY_FILE", "./notexistingfile")
+	cfg, err := config.NewFileConfigSource(&configFile).Load()
+	suite.NotNil(err)
+	suite.Nil(cfg)
+
+	ageKey, err := os.ReadFile("./testconfig.agekey")
+	suite.Nil(err)
+	suite.T().Setenv("SOPS_AGE_KEY", string(ageKey))
+	cfg2, err2 := config.NewFileConfigSource(&configFile).Load()
+	suite.Nil(err2)
+	suite.assertDecryptedConfig(cfg2)
+}
+
+func (suite *SopsTestSuite) TestUnencryptedConfig() {
+
+	cfg, err := config.NewStaticConfigSource("key2: value2\nsops: not encrypted\n").Load()
+	suite.Nil(err)
+	suite.Equal("value2", *cfg.Get("key2", nil))
+	suite.Equal("not encrypted", *cfg.Get("sops", nil))
+
+	content := []byte("key = \"sops\"")
+	decrypted, err := Decrypter{}.Decrypt(content, "toml")
+	suite.Nil(err)
+	suite.Equal(content, decrypted)
+}
+
+func (suite *SopsTestSuite) assertDecryptedConfig(cfg config.Config) {
+
+	suite.Equal("value1", *cfg.Get("namespace1.key1", nil))
+	suite.Equal("value2", *cfg.Get("key2", nil))
+	suite.Equal(12345, *cfg.GetAsInt("key3", nil))
+	suite.True(*cfg.GetAsBool("boolval", nil))
+	suite.Equal(43*time.Second, *cfg.GetAsDuration("durations.seconds", nil))
+	suite.Equal([]int{342543545, 3465567}, *cfg.GetAsIntSlice("intslice", nil))
+	suite.Equal("val1_2", cfg.GetAsSliceOfMaps("sliceofmaps")[0]["key1_2"])
+	suite.Equal("visible", *cfg.Get("public_unencrypted", nil))
+	suite.Equal("", *cfg.Get("empty", nil))
+	suite.Equal(int64(5368709120), *cfg.GetAsInt64("bytes", nil))
+	suite.Nil(cfg.Get("sops.mac", nil))
+}
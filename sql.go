@@ -0,0 +1,69 @@
+package config
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SQLConfigSource loads config from key/value rows of a database.
+type SQLConfigSource struct {
+
+	// Database to read config from.
+	db *sql.DB
+
+	// Query which returns config keys and values.
+	query string
+
+	// Optional arguments for the query.
+	args []any
+}
+
+// NewSQLConfigSource returns a new config source which reads config using given query. The query has
+// to return two columns, a config key path, e.g. database.host, and its value. Passed arguments
+// are used for placeholders of the query. Any driver supported by database/sql can be used.
+// Example query: SELECT name, value FROM settings WHERE service = $1
+func NewSQLConfigSource(db *sql.DB, query string, args ...any) ConfigSource {
+	return &SQLConfigSource{db: db, query: query, args: args}
+}
+
+// Load runs the query and returns all rows as ViperConfig. Keys are split into nested keys by dots.
+// Rows with a NULL value are ignored.
+func (source *SQLConfigSource) Load() (Config, error) {
+
+	rows, err := source.db.QueryContext(context.TODO(), source.query, source.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make(map[string]string)
+	for rows.Next() {
+		var key string
+		var value sql.NullString
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("unable to read config row: %w", err)
+		}
+		if value.Valid {
+			entries[key] = value.String
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Apply rows ordered by key, so values of nested keys are added to namespaces of parent keys.
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	settings := make(map[string]any)
+	for _, key := range keys {
+		setNestedValue(settings, strings.Split(key, "."), entries[key])
+	}
+	return newViperConfigFromMap(settings)
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// SSMConfigSource loads config from parameters in AWS SSM Parameter Store.
+type SSMConfigSource struct {
+
+	// AWS config for SSM access.
+	cfg aws.Config
+
+	// Parameter path, e.g. /myapp/prod/
+	path string
+
+	// Optional endpoint to use instead of default SSM endpoint.
+	endpoint *string
+}
+
+// NewSSMConfigSource returns a new config source which loads all parameters below given path.
+// If region is empty it will try to get current AWS region from environment variable AWS_REGION.
+// An endpoint can be passed to use a different endpoint than the default SSM endpoint.
+func NewSSMConfigSource(path string, region, endpoint *string) (ConfigSource, error) {
+
+	cfg, err := newAwsConfig(region)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SSMConfigSource{
+		cfg:      cfg,
+		path:     path,
+		endpoint: endpoint,
+	}, nil
+}
+
+// Load reads all parameters below the path of this source and pass them to a ViperConfig.
+// SecureString parameters will be decrypted. Parameter hierarchy is mapped to nested keys,
+// e.g. /myapp/prod/db/host will be available as db.host for path /myapp/prod/.
+// Values of StringList parameters are available as string slices.
+func (source *SSMConfigSource) Load() (Config, error) {
+
+	parameters, err := source.readParameters()
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]any)
+	for _, parameter := range parameters {
+
+		key := source.configKey(aws.ToString(parameter.Name))
+		if len(key) == 0 {
+			continue
+		}
+
+		var value any = aws.ToString(parameter.Value)
+		if parameter.Type == types.ParameterTypeStringList {
+			value = strings.Split(aws.ToString(parameter.Value), ",")
+		}
+		setNestedValue(settings, key, value)
+	}
+	return newViperConfigFromMap(settings)
+}
+
+// readParameters fetches all parameters below the path of this source.
+func (source *SSMConfigSource) readParameters() ([]types.Parameter, error) {
+
+	client := ssm.NewFromConfig(source.cfg, func(options *ssm.Options) {
+		if source.endpoint != nil {
+			options.BaseEndpoint = source.endpoint
+		}
+	})
+
+	var parameters []types.Parameter
+	paginator := ssm.NewGetParametersByPathPaginator(client, &ssm.GetParametersByPathInput{
+		Path:           aws.String(source.path),
+		Recursive:      aws.Bool(true),
+		WithDecryption: aws.Bool(true),
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return nil, err
+		}
+		parameters = append(parameters, output.Parameters...)
+	}
+	return parameters, nil
+}
+
+// configKey removes the path of this source from passed parameter name and
+// splits remaining name into a nested config key.
+func (source *SSMConfigSource) configKey(name string) []string {
+
+	name = strings.TrimPrefix(name, strings.TrimSuffix(source.path, "/"))
+
+	var key []string
+	for _, part := range strings.Split(name, "/") {
+		if part != "" {
+			key = append(key, part)
+		}
+	}
+	return key
+}
@@ -7,18 +7,28 @@ import (
 // StaticConfigSource uses a static config passed during creating this config source.
 type StaticConfigSource struct {
 
-	// Stativ config in YAML format.
-	yamlConfig string
+	// Static config content.
+	content string
+
+	// Optional format of static config, e.g. yaml or json.
+	configType string
 }
 
-// NewStaticConfigSource returns source with given static config values.
+// NewStaticConfigSource returns source with given static config values. Config is expected
+// in YAML format, or in the format set by SetViperConfigType.
 func NewStaticConfigSource(yamlConfig string) ConfigSource {
-	return &StaticConfigSource{yamlConfig: yamlConfig}
+	return &StaticConfigSource{content: yamlConfig}
+}
+
+// NewStaticConfigSourceWithFormat returns source with given static config values in passed format.
+// Supported formats are yaml, json, toml, properties and dotenv.
+func NewStaticConfigSourceWithFormat(config, format string) ConfigSource {
+	return &StaticConfigSource{content: config, configType: format}
 }
 
 // Load static config. This will create a new ViperConfig with static config content.
 func (source *StaticConfigSource) Load() (Config, error) {
 
-	reader := strings.NewReader(source.yamlConfig)
-	return newViperConfigFromReader(reader)
+	reader := strings.NewReader(source.content)
+	return newViperConfigFromReader(reader, source.configType)
 }
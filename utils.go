@@ -1,7 +1,12 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"io"
+	"math"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -16,11 +21,27 @@ var (
 )
 
 // newViperConfigFromReader returns a viper config for content provided by passed reader.
-func newViperConfigFromReader(reader io.Reader) (Config, error) {
+// Content is parsed in passed format, e.g. yaml, json, toml or properties. If format is empty
+// the config type set by SetViperConfigType will be used.
+// Encrypted content will be decrypted by all decrypters added with RegisterDecrypter.
+func newViperConfigFromReader(reader io.Reader, configType string) (Config, error) {
 
-	viperConfig := viper.New()
-	viperConfig.SetConfigType("yaml")
-	if err := viperConfig.ReadConfig(reader); err != nil {
+	if configType == "" {
+		configType = viperConfigType
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	content, err = decryptContent(content, configType)
+	if err != nil {
+		return nil, err
+	}
+
+	viperConfig := viper.NewWithOptions(viper.WithDecoderRegistry(configDecoders))
+	viperConfig.SetConfigType(configType)
+	if err := viperConfig.ReadConfig(bytes.NewReader(content)); err != nil {
 		return nil, err
 	}
 	return &ViperConfig{config: viperConfig}, nil
@@ -31,6 +52,21 @@ func AsIntPtr(v int) *int {
 	return &v
 }
 
+// AsInt64Ptr returns given value as pointer.
+func AsInt64Ptr(v int64) *int64 {
+	return &v
+}
+
+// AsUint64Ptr returns given value as pointer.
+func AsUint64Ptr(v uint64) *uint64 {
+	return &v
+}
+
+// AsFloatPtr returns given value as pointer.
+func AsFloatPtr(v float64) *float64 {
+	return &v
+}
+
 // AsStringPtr return given value as pointer.
 func AsStringPtr(v string) *string {
 	return &v
@@ -96,3 +132,189 @@ func extractNumbers(strValue string) *string {
 func isValidDuration(value string) bool {
 	return durationRegexp.MatchString(value)
 }
+
+// newViperConfigFromMap returns a viper config which contains all values of passed map.
+// Nested maps will be available as nested config keys.
+func newViperConfigFromMap(settings map[string]any) (Config, error) {
+
+	viperConfig := viper.New()
+	if err := viperConfig.MergeConfigMap(settings); err != nil {
+		return nil, err
+	}
+	return &ViperConfig{config: viperConfig}, nil
+}
+
+// setNestedValue assigns passed value to given key path in a config tree. Missing
+// namespaces are created on the fly and a value which is in the way of a namespace
+// will be replaced by this namespace.
+func setNestedValue(tree map[string]any, path []string, value any) {
+
+	if len(path) == 0 {
+		return
+	}
+	for _, key := range path[:len(path)-1] {
+		subTree, ok := tree[key].(map[string]any)
+		if !ok {
+			subTree = make(map[string]any)
+			tree[key] = subTree
+		}
+		tree = subTree
+	}
+	tree[path[len(path)-1]] = value
+}
+
+// mergeSettings deep merges all values from source into passed target config tree.
+// Values from source will overwrite existing values in target.
+func mergeSettings(target, source map[string]any) {
+
+	for key, value := range source {
+		sourceTree, sourceIsTree := value.(map[string]any)
+		targetTree, targetIsTree := target[key].(map[string]any)
+		if sourceIsTree && targetIsTree {
+			mergeSettings(targetTree, sourceTree)
+			continue
+		}
+		if sourceIsTree {
+			subTree := make(map[string]any)
+			mergeSettings(subTree, sourceTree)
+			value = subTree
+		}
+		target[key] = value
+	}
+}
+
+// maxExactFloat is the largest integer, 2^53-1, which is known to be exact if read from a float64.
+// Larger integers, e.g. from JSON which decodes all numbers to float64, may already
+// have lost precision and are rejected.
+const maxExactFloat = 1<<53 - 1
+
+// toInt converts passed config value to an integer which fits into given bit size.
+// Returns strconv.ErrRange if the value overflows, or if it's a float of 2^53 or more
+// which may have lost precision, and an error if the value is not an integer, e.g. 1.5 or abc.
+func toInt(value any, bitSize int) (int64, error) {
+
+	var number int64
+	switch rv := reflect.ValueOf(value); rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number = rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			return 0, strconv.ErrRange
+		}
+		number = int64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		floatValue := rv.Float()
+		if floatValue != math.Trunc(floatValue) {
+			return 0, errors.New("not an integer")
+		}
+		if math.Abs(floatValue) > maxExactFloat {
+			return 0, strconv.ErrRange
+		}
+		number = int64(floatValue)
+	case reflect.String:
+		return strconv.ParseInt(strings.TrimSpace(rv.String()), 10, bitSize)
+	default:
+		return 0, fmt.Errorf("unsupported type %T", value)
+	}
+
+	if bitSize < 64 && (number < -1<<(bitSize-1) || number > 1<<(bitSize-1)-1) {
+		return 0, strconv.ErrRange
+	}
+	return number, nil
+}
+
+// toUint converts passed config value to an unsigned integer which fits into given bit size.
+// Returns strconv.ErrRange if the value is negative, overflows or is a float of 2^53 or more
+// which may have lost precision, and an error if the value is not an integer.
+func toUint(value any, bitSize int) (uint64, error) {
+
+	var number uint64
+	switch rv := reflect.ValueOf(value); rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if rv.Int() < 0 {
+			return 0, strconv.ErrRange
+		}
+		number = uint64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		number = rv.Uint()
+	case reflect.Float32, reflect.Float64:
+		floatValue := rv.Float()
+		if floatValue != math.Trunc(floatValue) {
+			return 0, errors.New("not an integer")
+		}
+		if floatValue < 0 || floatValue > maxExactFloat {
+			return 0, strconv.ErrRange
+		}
+		number = uint64(floatValue)
+	case reflect.String:
+		return strconv.ParseUint(strings.TrimSpace(rv.String()), 10, bitSize)
+	default:
+		return 0, fmt.Errorf("unsupported type %T", value)
+	}
+
+	if bitSize < 64 && number > 1<<bitSize-1 {
+		return 0, strconv.ErrRange
+	}
+	return number, nil
+}
+
+// toFloat converts passed config value to a float with given bit size.
+// Returns strconv.ErrRange if the value overflows.
+func toFloat(value any, bitSize int) (float64, error) {
+
+	var number float64
+	switch rv := reflect.ValueOf(value); rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number = float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		number = float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		number = rv.Float()
+	case reflect.String:
+		return strconv.ParseFloat(strings.TrimSpace(rv.String()), bitSize)
+	default:
+		return 0, fmt.Errorf("unsupported type %T", value)
+	}
+
+	if bitSize == 32 && math.Abs(number) > math.MaxFloat32 && !math.IsInf(number, 0) {
+		return 0, strconv.ErrRange
+	}
+	return number, nil
+}
+
+// toComplex converts passed config value to a complex number with given bit size, e.g. 128
+// for complex128. Strings are parsed by strconv.ParseComplex, e.g. 1+2i, all other numbers
+// are used as real part. Returns strconv.ErrRange if the value overflows.
+func toComplex(value any, bitSize int) (complex128, error) {
+
+	switch rv := reflect.ValueOf(value); rv.Kind() {
+	case reflect.Complex64, reflect.Complex128:
+		number := rv.Complex()
+		if bitSize == 64 && (math.Abs(real(number)) > math.MaxFloat32 || math.Abs(imag(number)) > math.MaxFloat32) {
+			return 0, strconv.ErrRange
+		}
+		return number, nil
+	case reflect.String:
+		return strconv.ParseComplex(strings.TrimSpace(rv.String()), bitSize)
+	default:
+		number, err := toFloat(value, bitSize/2)
+		if err != nil {
+			return 0, err
+		}
+		return complex(number, 0), nil
+	}
+}
+
+// toBool converts passed config value to a bool. Strings and numbers are converted
+// by strconv.ParseBool, so 1, t, true, 0, f and false are valid values.
+func toBool(value any) (bool, error) {
+
+	switch boolValue := value.(type) {
+	case bool:
+		return boolValue, nil
+	case nil:
+		return false, strconv.ErrSyntax
+	default:
+		return strconv.ParseBool(strings.TrimSpace(fmt.Sprint(value)))
+	}
+}
@@ -1,9 +1,14 @@
 package config
 
 import (
+	"bytes"
+	"compress/gzip"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/suite"
 	//"log"
 
@@ -21,12 +26,12 @@ func TestUtilsTestSuite(t *testing.T) {
 func (suite *UtilsTestSuite) TestNewViperConfigFromReader() {
 
 	configStr1 := "key: val"
-	config1, err1 := newViperConfigFromReader(strings.NewReader(configStr1))
+	config1, err1 := newViperConfigFromReader(strings.NewReader(configStr1), "")
 	suite.Nil(err1)
 	suite.NotNil(config1)
 
 	configStr2 := "key1=val1"
-	config2, err2 := newViperConfigFromReader(strings.NewReader(configStr2))
+	config2, err2 := newViperConfigFromReader(strings.NewReader(configStr2), "")
 	suite.NotNil(err2)
 	suite.Nil(config2)
 
@@ -49,6 +54,18 @@ func (suite *UtilsTestSuite) TestPointerConverter() {
 	v4 := 2 * time.Second
 	p4 := AsDurationPtr(v4)
 	suite.Equal(v4, *p4)
+
+	v5 := int64(5368709120)
+	p5 := AsInt64Ptr(v5)
+	suite.Equal(v5, *p5)
+
+	v6 := uint64(18446744073709551615)
+	p6 := AsUint64Ptr(v6)
+	suite.Equal(v6, *p6)
+
+	v7 := 0.75
+	p7 := AsFloatPtr(v7)
+	suite.Equal(v7, *p7)
 }
 
 func (suite *UtilsTestSuite) TestConvertToDuration() {
@@ -102,8 +119,212 @@ func (suite *UtilsTestSuite) TestIsValidDuration() {
 func (suite *UtilsTestSuite) TestSetViperConfigType() {
 
 	suite.Equal("yaml", viperConfigType)
+	defer SetViperConfigType("yaml")
 
 	jsonConfigType := "json"
 	SetViperConfigType(jsonConfigType)
 	suite.Equal(jsonConfigType, viperConfigType)
+
+	config1, err1 := newViperConfigFromReader(strings.NewReader("{\"key\": \"val\"}"), "")
+	suite.Nil(err1)
+	suite.Equal("val", *config1.Get("key", nil))
+
+	config2, err2 := NewStaticConfigSource("{\"key\": \"val\"}").Load()
+	suite.Nil(err2)
+	suite.Equal("val", *config2.Get("key", nil))
+}
+
+func (suite *UtilsTestSuite) TestNewViperConfigFromReaderWithFormat() {
+
+	for format, content := range map[string]string{
+		"yaml":       "namespace1:\n  key1: value1\n",
+		"json":       "{\"namespace1\": {\"key1\": \"value1\"}}",
+		"toml":       "[namespace1]\nkey1 = \"value1\"\n",
+		"properties": "namespace1.key1 = value1\n",
+		"props":      "namespace1.key1=value1\n",
+		"dotenv":     "namespace1.key1=value1\n",
+	} {
+		config, err := newViperConfigFromReader(strings.NewReader(content), format)
+		suite.Nil(err, format)
+		suite.Equal("value1", *config.Get("namespace1.key1", nil), format)
+	}
+
+	config, err := newViperConfigFromReader(strings.NewReader("key: val"), "xxx")
+	suite.NotNil(err)
+	suite.Nil(config)
+}
+
+func (suite *UtilsTestSuite) TestConfigTypeDetection() {
+
+	suite.Equal("yml", configTypeFromFileName("config.yml"))
+	suite.Equal("yaml", configTypeFromFileName("/etc/go_config/config.YAML"))
+	suite.Equal("json", configTypeFromFileName("configs/app.json"))
+	suite.Equal("toml", configTypeFromFileName("app.toml"))
+	suite.Equal("properties", configTypeFromFileName("app.properties"))
+	suite.Equal("", configTypeFromFileName("app.txt"))
+	suite.Equal("", configTypeFromFileName("app"))
+
+	suite.Equal("yaml", configTypeFromContentType("application/x-yaml"))
+	suite.Equal("json", configTypeFromContentType("application/json; charset=utf-8"))
+	suite.Equal("toml", configTypeFromContentType("application/toml"))
+	suite.Equal("properties", configTypeFromContentType("text/x-java-properties"))
+	suite.Equal("", configTypeFromContentType("binary/octet-stream"))
+	suite.Equal("", configTypeFromContentType(""))
+}
+
+func (suite *UtilsTestSuite) TestMergeSettings() {
+
+	target := map[string]any{
+		"key1": "value1",
+		"namespace1": map[string]any{
+			"key1": "value1",
+			"key2": "value2",
+		},
+		"key2": "value2",
+	}
+	source := map[string]any{
+		"namespace1": map[string]any{
+			"key2": "value3",
+		},
+		"key2": map[string]any{
+			"key1": "value4",
+		},
+	}
+	mergeSettings(target, source)
+	suite.Equal(map[string]any{
+		"key1": "value1",
+		"namespace1": map[string]any{
+			"key1": "value1",
+			"key2": "value3",
+		},
+		"key2": map[string]any{
+			"key1": "value4",
+		},
+	}, target)
+}
+
+func (suite *UtilsTestSuite) TestDecompress() {
+
+	content := []byte("key: val")
+
+	var gzipContent bytes.Buffer
+	gzipWriter := gzip.NewWriter(&gzipContent)
+	gzipWriter.Write(content)
+	gzipWriter.Close()
+
+	zstdEncoder, err := zstd.NewWriter(nil)
+	suite.Nil(err)
+	zstdContent := zstdEncoder.EncodeAll(content, nil)
+
+	for _, compressedContent := range [][]byte{content, gzipContent.Bytes(), zstdContent} {
+		decompressedContent, err := decompress(compressedContent, "")
+		suite.Nil(err)
+		suite.Equal(content, decompressedContent)
+	}
+
+	decompressedContent, err := decompress(gzipContent.Bytes(), "gzip")
+	suite.Nil(err)
+	suite.Equal(content, decompressedContent)
+
+	_, err = decompress(content, "gzip")
+	suite.NotNil(err)
+	_, err = decompress(content, "zstd")
+	suite.NotNil(err)
+
+	suite.Equal("config.yml", trimCompressionExtension("config.yml.gz"))
+	suite.Equal("config.json", trimCompressionExtension("config.json.zst"))
+	suite.Equal("config.yml", trimCompressionExtension("config.yml"))
+	suite.Equal("yml", configTypeFromFileName("configs/config.yml.gz"))
+	suite.Equal("json", configTypeFromFileName("configs/config.json.zstd"))
+}
+
+func (suite *UtilsTestSuite) TestNumericConversion() {
+
+	for _, value := range []any{42, int8(42), uint64(42), 42.0, "42", " 42 "} {
+		number, err := toInt(value, 64)
+		suite.Nil(err, value)
+		suite.Equal(int64(42), number, value)
+
+		unsignedNumber, err := toUint(value, 64)
+		suite.Nil(err, value)
+		suite.Equal(uint64(42), unsignedNumber, value)
+
+		floatNumber, err := toFloat(value, 64)
+		suite.Nil(err, value)
+		suite.Equal(42.0, floatNumber, value)
+	}
+
+	number, err := toInt(int64(math.MinInt64), 64)
+	suite.Nil(err)
+	suite.Equal(int64(math.MinInt64), number)
+	_, err = toInt(uint64(math.MaxUint64), 64)
+	suite.ErrorIs(err, strconv.ErrRange)
+	_, err = toInt(128, 8)
+	suite.ErrorIs(err, strconv.ErrRange)
+	_, err = toInt(-129, 8)
+	suite.ErrorIs(err, strconv.ErrRange)
+	_, err = toInt(1e19, 64)
+	suite.ErrorIs(err, strconv.ErrRange)
+	number, err = toInt(float64(1<<53-1), 64)
+	suite.Nil(err)
+	suite.Equal(int64(1<<53-1), number)
+	_, err = toInt(float64(1<<53), 64)
+	suite.ErrorIs(err, strconv.ErrRange)
+	_, err = toInt(-float64(1<<53+2), 64)
+	suite.ErrorIs(err, strconv.ErrRange)
+	_, err = toInt("2147483648", 32)
+	suite.ErrorIs(err, strconv.ErrRange)
+	_, err = toInt(1.5, 64)
+	suite.NotNil(err)
+	_, err = toInt("abc", 64)
+	suite.ErrorIs(err, strconv.ErrSyntax)
+	_, err = toInt([]int{1}, 64)
+	suite.NotNil(err)
+
+	unsignedNumber, err := toUint(uint64(math.MaxUint64), 64)
+	suite.Nil(err)
+	suite.Equal(uint64(math.MaxUint64), unsignedNumber)
+	_, err = toUint(-1, 64)
+	suite.ErrorIs(err, strconv.ErrRange)
+	_, err = toUint(256, 8)
+	suite.ErrorIs(err, strconv.ErrRange)
+	_, err = toUint(2e19, 64)
+	suite.ErrorIs(err, strconv.ErrRange)
+	_, err = toUint(float64(1<<53+2), 64)
+	suite.ErrorIs(err, strconv.ErrRange)
+	_, err = toUint("-1", 64)
+	suite.NotNil(err)
+
+	_, err = toFloat(1e300, 32)
+	suite.ErrorIs(err, strconv.ErrRange)
+	_, err = toFloat("1e300", 32)
+	suite.ErrorIs(err, strconv.ErrRange)
+	_, err = toFloat(true, 64)
+	suite.NotNil(err)
+
+	complexNumber, err := toComplex("1.5-2i", 128)
+	suite.Nil(err)
+	suite.Equal(complex(1.5, -2), complexNumber)
+	complexNumber, err = toComplex(complex64(complex(1, 1)), 64)
+	suite.Nil(err)
+	suite.Equal(complex(1, 1), complexNumber)
+	complexNumber, err = toComplex(uint64(42), 128)
+	suite.Nil(err)
+	suite.Equal(complex(42, 0), complexNumber)
+	_, err = toComplex(1e300, 64)
+	suite.ErrorIs(err, strconv.ErrRange)
+	_, err = toComplex(complex(1e300, 0), 64)
+	suite.ErrorIs(err, strconv.ErrRange)
+	_, err = toComplex("abc", 128)
+	suite.NotNil(err)
+
+	for value, expected := range map[any]bool{true: true, "false": false, "1": true, 0: false, " t ": true} {
+		b, err := toBool(value)
+		suite.Nil(err, value)
+		suite.Equal(expected, b, value)
+	}
+	_, err = toBool("yes")
+	suite.NotNil(err)
+	_, err = toBool(nil)
+	suite.NotNil(err)
 }
@@ -0,0 +1,179 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// vaultAppRoleLoginPath is the API path used to login with AppRole credentials.
+const vaultAppRoleLoginPath = "/v1/auth/approle/login"
+
+// VaultSecret defines a secret in a Vault KV v2 secrets engine and
+// the key prefix its data will be available at.
+type VaultSecret struct {
+
+	// Mount path of the KV v2 secrets engine, e.g. secret.
+	Mount string
+
+	// Path of the secret within the secrets engine, e.g. myapp/database.
+	Path string
+
+	// Dot separated key prefix, e.g. database. Data of a secret without
+	// a key prefix will be available at root level.
+	KeyPrefix string
+}
+
+// VaultConfigSource loads config from secrets in a HashiCorp Vault KV v2 secrets engine.
+type VaultConfigSource struct {
+
+	// Address of the Vault server, e.g. https://vault.example.com:8200.
+	address string
+
+	// Token used to authenticate requests.
+	token *string
+
+	// Role id for AppRole authentication.
+	roleId *string
+
+	// Secret id for AppRole authentication.
+	secretId *string
+
+	// Secrets to load.
+	secrets []VaultSecret
+
+	// Client used to send requests.
+	client *http.Client
+}
+
+// NewVaultConfigSource returns a config source which loads all given secrets
+// from a Vault server and uses passed token for authentication.
+func NewVaultConfigSource(address, token string, secrets []VaultSecret) ConfigSource {
+	return &VaultConfigSource{
+		address: address,
+		token:   &token,
+		secrets: secrets,
+		client:  &http.Client{Timeout: defaultHTTPTimeout},
+	}
+}
+
+// NewVaultAppRoleConfigSource returns a config source which loads all given secrets
+// from a Vault server. It will login with passed AppRole credentials on each load
+// to obtain a token.
+func NewVaultAppRoleConfigSource(address, roleId, secretId string, secrets []VaultSecret) ConfigSource {
+	return &VaultConfigSource{
+		address:  address,
+		roleId:   &roleId,
+		secretId: &secretId,
+		secrets:  secrets,
+		client:   &http.Client{Timeout: defaultHTTPTimeout},
+	}
+}
+
+// Load reads all secrets and pass them to a ViperConfig. Data of each secret is available
+// at its key prefix, e.g. value for key password of a secret with key prefix database
+// is available at database.password. Secrets are merged in given order, so a later
+// secret will overwrite values of a previous one.
+func (source *VaultConfigSource) Load() (Config, error) {
+
+	token, err := source.authToken()
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]any)
+	for _, secret := range source.secrets {
+
+		data, err := source.readSecret(secret, token)
+		if err != nil {
+			return nil, err
+		}
+
+		secretSettings := data
+		if secret.KeyPrefix != "" {
+			secretSettings = make(map[string]any)
+			setNestedValue(secretSettings, strings.Split(secret.KeyPrefix, "."), data)
+		}
+		mergeSettings(settings, secretSettings)
+	}
+	return newViperConfigFromMap(settings)
+}
+
+// authToken returns the token to authenticate requests. If AppRole credentials
+// are used a login will be performed to obtain a new token.
+func (source *VaultConfigSource) authToken() (string, error) {
+
+	if source.token != nil {
+		return *source.token, nil
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"role_id":   *source.roleId,
+		"secret_id": *source.secretId,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var login struct {
+		Auth *struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	if err := source.send(http.MethodPost, vaultAppRoleLoginPath, nil, payload, &login); err != nil {
+		return "", err
+	}
+	if login.Auth == nil || login.Auth.ClientToken == "" {
+		return "", fmt.Errorf("no client token in AppRole login response from %s", source.address)
+	}
+	return login.Auth.ClientToken, nil
+}
+
+// readSecret returns data of latest version of passed secret.
+func (source *VaultConfigSource) readSecret(secret VaultSecret, token string) (map[string]any, error) {
+
+	path := fmt.Sprintf("/v1/%s/data/%s", strings.Trim(secret.Mount, "/"), strings.Trim(secret.Path, "/"))
+	var response struct {
+		Data *struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := source.send(http.MethodGet, path, &token, nil, &response); err != nil {
+		return nil, err
+	}
+	if response.Data == nil || response.Data.Data == nil {
+		return nil, fmt.Errorf("no data for secret %s/%s", secret.Mount, secret.Path)
+	}
+	return response.Data.Data, nil
+}
+
+// send sends a request to given API path of the Vault server and decodes the JSON response into result.
+func (source *VaultConfigSource) send(method, path string, token *string, body []byte, result any) error {
+
+	endpoint, err := url.JoinPath(source.address, path)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if token != nil {
+		request.Header.Set("X-Vault-Token", *token)
+	}
+
+	response, err := source.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response status for %s: %s", endpoint, response.Status)
+	}
+	return json.NewDecoder(response.Body).Decode(result)
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"reflect"
+	"sort"
 	"strconv"
 	"time"
 
@@ -55,6 +57,57 @@ func (conf *ViperConfig) GetAsIntSlice(key string, defaultValue *[]int) *[]int {
 	return defaultValue
 }
 
+// GetAsInt64 returns config value for passed key as int64 or given default value
+// if there's no value for this key, the value is not an integer or overflows int64.
+func (conf *ViperConfig) GetAsInt64(key string, defaultValue *int64) *int64 {
+	if conf.config.IsSet(key) {
+		if value, err := toInt(conf.config.Get(key), 64); err == nil {
+			return &value
+		}
+	}
+	return defaultValue
+}
+
+// GetAsInt64Slice returns config values for passed key as int64 slice or given default
+// value if there's no value for this key or one of the values can't be converted.
+func (conf *ViperConfig) GetAsInt64Slice(key string, defaultValue *[]int64) *[]int64 {
+	return getAsSlice(conf, key, defaultValue)
+}
+
+// GetAsUint64 returns config value for passed key as uint64 or given default value
+// if there's no value for this key, the value is not an integer, negative or overflows uint64.
+func (conf *ViperConfig) GetAsUint64(key string, defaultValue *uint64) *uint64 {
+	if conf.config.IsSet(key) {
+		if value, err := toUint(conf.config.Get(key), 64); err == nil {
+			return &value
+		}
+	}
+	return defaultValue
+}
+
+// GetAsUint64Slice returns config values for passed key as uint64 slice or given default
+// value if there's no value for this key or one of the values can't be converted.
+func (conf *ViperConfig) GetAsUint64Slice(key string, defaultValue *[]uint64) *[]uint64 {
+	return getAsSlice(conf, key, defaultValue)
+}
+
+// GetAsFloat returns config value for passed key as float64 or given default value
+// if there's no value for this key or the value is not a number.
+func (conf *ViperConfig) GetAsFloat(key string, defaultValue *float64) *float64 {
+	if conf.config.IsSet(key) {
+		if value, err := toFloat(conf.config.Get(key), 64); err == nil {
+			return &value
+		}
+	}
+	return defaultValue
+}
+
+// GetAsFloatSlice returns config values for passed key as float64 slice or given default
+// value if there's no value for this key or one of the values can't be converted.
+func (conf *ViperConfig) GetAsFloatSlice(key string, defaultValue *[]float64) *[]float64 {
+	return getAsSlice(conf, key, defaultValue)
+}
+
 // GetAsBool returns config value as bool or given default value
 // if there's no value for this key or conversion to bool fails.
 func (conf *ViperConfig) GetAsBool(key string, defaultValue *bool) *bool {
@@ -81,6 +134,57 @@ func (conf *ViperConfig) GetAsDuration(key string, defaultValue *time.Duration)
 	return defaultValue
 }
 
+// GetIntE returns config value for passed key as int. It returns an error which wraps
+// ErrKeyNotFound if there's no value for this key or a ConversionError if the value
+// is not an integer or overflows int.
+func (conf *ViperConfig) GetIntE(key string) (int, error) {
+
+	if !conf.config.IsSet(key) {
+		return 0, keyNotFoundError(key)
+	}
+	value := conf.config.Get(key)
+	number, err := toInt(value, strconv.IntSize)
+	if err != nil {
+		return 0, &ConversionError{Key: key, Value: value, Type: "int", Err: err}
+	}
+	return int(number), nil
+}
+
+// GetBoolE returns config value for passed key as bool. It returns an error which wraps
+// ErrKeyNotFound if there's no value for this key or a ConversionError if the value
+// is not a valid bool.
+func (conf *ViperConfig) GetBoolE(key string) (bool, error) {
+
+	if !conf.config.IsSet(key) {
+		return false, keyNotFoundError(key)
+	}
+	value := conf.config.Get(key)
+	b, err := toBool(value)
+	if err != nil {
+		return false, &ConversionError{Key: key, Value: value, Type: "bool", Err: err}
+	}
+	return b, nil
+}
+
+// GetDurationE returns config value for passed key as duration, using the same units
+// as GetAsDuration. It returns an error which wraps ErrKeyNotFound if there's no value
+// for this key or a ConversionError if the value is not a valid duration.
+func (conf *ViperConfig) GetDurationE(key string) (time.Duration, error) {
+
+	if !conf.config.IsSet(key) {
+		return 0, keyNotFoundError(key)
+	}
+	value := conf.config.Get(key)
+	if duration, ok := value.(time.Duration); ok {
+		return duration, nil
+	}
+	duration := toDuration(conf.config.GetString(key))
+	if duration == nil {
+		return 0, &ConversionError{Key: key, Value: value, Type: "time.Duration"}
+	}
+	return *duration, nil
+}
+
 // GetAsSliceOfMaps returns local config values as slice of maps.
 func (conf *ViperConfig) GetAsSliceOfMaps(key string) []map[string]string {
 
@@ -121,3 +225,42 @@ func (conf *ViperConfig) toStringMap(interfaceMap map[string]interface{}) map[st
 func (conf *ViperConfig) Unmarshal(rawVal any) error {
 	return conf.config.Unmarshal(rawVal)
 }
+
+// Sub returns config for the subtree at passed key. Keys of returned config are
+// relative to this subtree, e.g. database.host becomes host for Sub("database").
+// Returns an empty config if there's no subtree for passed key.
+func (conf *ViperConfig) Sub(key string) Config {
+	if subConfig := conf.config.Sub(key); subConfig != nil {
+		return &ViperConfig{config: subConfig}
+	}
+	return &ViperConfig{config: viper.New()}
+}
+
+// Keys returns all keys of this config in dot notation, sorted alphabetically.
+func (conf *ViperConfig) Keys() []string {
+	keys := conf.config.AllKeys()
+	sort.Strings(keys)
+	return keys
+}
+
+// Has returns true if there's a config value for passed key.
+func (conf *ViperConfig) Has(key string) bool {
+	return conf.config.IsSet(key)
+}
+
+// AllSettings returns the whole config as nested map.
+func (conf *ViperConfig) AllSettings() map[string]any {
+	return conf.config.AllSettings()
+}
+
+// getAsSlice returns config values for passed key converted to a slice of type T or given
+// default value if there's no value for this key or one of the values can't be converted.
+func getAsSlice[T any](conf *ViperConfig, key string, defaultValue *[]T) *[]T {
+	if conf.config.IsSet(key) {
+		var values []T
+		if err := convertSlice(conf.config.Get(key), reflect.ValueOf(&values).Elem()); err == nil {
+			return &values
+		}
+	}
+	return defaultValue
+}